@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/digest"
+	"review-assigner/internal/notify"
+	"review-assigner/internal/outbox"
+	"review-assigner/internal/rest"
+	"review-assigner/internal/service"
+	"review-assigner/internal/sla"
+	"review-assigner/internal/storage"
+	"review-assigner/internal/storage/postgres"
+	"review-assigner/internal/storage/sqlite"
+)
+
+func main() {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = runMigrate(os.Args[2:])
+	} else {
+		err = run()
+	}
+	if err != nil {
+		slog.Error("review-assigner stopped with error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: cfg.LogLevel})))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	st, closeStorage, err := openStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeStorage()
+
+	sinks := outboxSinks(st, *cfg.Notify)
+	svc, err := service.NewService(st, *cfg.Selection, outbox.SinkNames(sinks))
+	if err != nil {
+		return err
+	}
+
+	authenticator, err := rest.NewAuthenticator(*cfg.Auth, svc)
+	if err != nil {
+		return err
+	}
+
+	// jobs are stopped explicitly on every return path, storage is closed after them
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+
+	digestDone, err := startDigestJob(jobsCtx, svc, *cfg.Digest)
+	if err != nil {
+		return err
+	}
+	outboxDone := startOutboxDispatcher(jobsCtx, st, *cfg.Outbox, sinks)
+	slaDone := startSLAScanner(jobsCtx, svc, *cfg.SLA)
+
+	srv := &http.Server{
+		Addr:    cfg.Address,
+		Handler: rest.NewRouter(svc, authenticator, *cfg.Webhook),
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("starting http server", "address", cfg.Address)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("http server failed: %w", err)
+		}
+		close(serveErr)
+	}()
+
+	var runErr error
+	select {
+	case runErr = <-serveErr:
+	case <-ctx.Done():
+	}
+
+	stopJobs()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if runErr == nil {
+		slog.Info("shutting down http server", "timeout", cfg.ShutdownTimeout)
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			runErr = fmt.Errorf("failed to shutdown http server: %w", err)
+		} else {
+			slog.Info("http server stopped")
+		}
+	}
+
+	if err := waitJobs(shutdownCtx, outboxDone, digestDone, slaDone); err != nil {
+		return errors.Join(runErr, err)
+	}
+
+	return runErr
+}
+
+// waitJobs waits until every job closes its done channel or ctx is done.
+func waitJobs(ctx context.Context, jobs ...<-chan struct{}) error {
+	for _, done := range jobs {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return fmt.Errorf("background jobs did not stop in time: %w", ctx.Err())
+		}
+	}
+	return nil
+}
+
+// outboxSinks returns sinks enabled by config.
+func outboxSinks(st storage.Storage, notifyCfg config.NotifyConfig) []outbox.Sink {
+	var sinks []outbox.Sink
+	if len(notifyCfg.WebhookURLs) > 0 {
+		sender := notify.NewWebhookSender(notifyCfg.WebhookSecret, notifyCfg.Timeout)
+		for i, endpoint := range notifyCfg.WebhookURLs {
+			sinks = append(sinks, notify.NewWebhookSink(notify.WebhookSinkName(i), endpoint, sender))
+		}
+	}
+	if notifyCfg.ChatWebhookURL != "" {
+		sinks = append(sinks, notify.NewChatSink(notifyCfg.ChatWebhookURL, notifyCfg.ChatFormat, st, notifyCfg.Timeout))
+	}
+	return sinks
+}
+
+// startOutboxDispatcher runs dispatching of outbox messages until ctx is done if any sink is enabled.
+// Returned channel is closed when dispatcher stops.
+func startOutboxDispatcher(ctx context.Context, st storage.Storage, cfg config.OutboxConfig, sinks []outbox.Sink) <-chan struct{} {
+	done := make(chan struct{})
+	if len(sinks) == 0 {
+		close(done)
+		return done
+	}
+
+	dispatcher := outbox.NewDispatcher(st, cfg, sinks...)
+	go func() {
+		defer close(done)
+		slog.Info("starting outbox dispatcher", "sinks", len(sinks))
+		dispatcher.Run(ctx)
+		slog.Info("outbox dispatcher stopped")
+	}()
+
+	return done
+}
+
+// startDigestJob runs sending of daily digests until ctx is done if digests are enabled.
+// Returned channel is closed when job stops.
+func startDigestJob(ctx context.Context, svc *service.Service, cfg config.DigestConfig) (<-chan struct{}, error) {
+	done := make(chan struct{})
+	if cfg.SMTPAddr == "" {
+		close(done)
+		return done, nil
+	}
+
+	job, err := digest.NewJob(svc, cfg)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer close(done)
+		slog.Info("starting digest job", "smtp_address", cfg.SMTPAddr, "send_at", time.Duration(cfg.SendAt))
+		job.Run(ctx)
+		slog.Info("digest job stopped")
+	}()
+
+	return done, nil
+}
+
+// startSLAScanner runs escalation of stale pull requests until ctx is done.
+// Teams without review SLA are not affected. Returned channel is closed when scanner stops.
+func startSLAScanner(ctx context.Context, svc *service.Service, cfg config.SLAConfig) <-chan struct{} {
+	done := make(chan struct{})
+
+	scanner := sla.NewScanner(svc, cfg)
+	go func() {
+		defer close(done)
+		slog.Info("starting sla scanner", "scan_interval", cfg.ScanInterval)
+		scanner.Run(ctx)
+		slog.Info("sla scanner stopped")
+	}()
+
+	return done
+}
+
+// openStorage connects to storage backend chosen by cfg.DBDriver.
+// Returned func must be called to close storage.
+func openStorage(ctx context.Context, cfg config.Config) (storage.Storage, func(), error) {
+	switch cfg.DBDriver {
+	case config.DriverSQLite:
+		st, err := sqlite.New(ctx, *cfg.SQLite)
+		if err != nil {
+			return nil, nil, err
+		}
+		return st, st.Close, nil
+	default:
+		st, err := postgres.New(ctx, *cfg.DB)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cfg.DB.MigrateOnStartup {
+			if err := st.MigrateUp(ctx); err != nil {
+				st.Close()
+				return nil, nil, err
+			}
+		}
+		return st, st.Close, nil
+	}
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v6"
@@ -12,15 +18,159 @@ type Config struct {
 	LogLevel        slog.Level    `env:"APP_LOG_LEVEL"`
 	Address         string        `env:"APP_ADDRESS,required"`
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT,required"`
-	DB              *DBConfig
+	// DBDriver chooses storage backend: "postgres" or "sqlite".
+	DBDriver  string `env:"DB_DRIVER" envDefault:"postgres"`
+	DB        *DBConfig
+	SQLite    *SQLiteConfig
+	Selection *SelectionConfig
+	Auth      *AuthConfig
+	Webhook   *WebhookConfig
+	Notify    *NotifyConfig
+	Outbox    *OutboxConfig
+	Digest    *DigestConfig
+	SLA       *SLAConfig
 }
 
+// Storage backends selectable with DB_DRIVER.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 type DBConfig struct {
 	Host string `env:"DB_HOST,required"`
 	Port int    `env:"DB_PORT,required"`
 	User string `env:"DB_USER,required"`
 	Pass string `env:"DB_PASSWORD,required"`
 	Name string `env:"DB_NAME,required"`
+	// MigrateOnStartup applies pending migrations before server starts.
+	MigrateOnStartup bool `env:"DB_MIGRATE_ON_STARTUP"`
+}
+
+// SQLiteConfig is used instead of DBConfig when DB_DRIVER is sqlite.
+type SQLiteConfig struct {
+	// Path to database file, created if it does not exist.
+	Path string `env:"DB_PATH,required"`
+}
+
+// Authentication modes selectable with AUTH_MODE.
+const (
+	// AuthModeToken authenticates with X-Admin-Token and X-User-Token headers.
+	AuthModeToken = "token"
+	// AuthModeJWT authenticates with JWT in Authorization: Bearer header.
+	AuthModeJWT = "jwt"
+)
+
+// AuthConfig describes how api requests are authenticated.
+type AuthConfig struct {
+	Mode string `env:"AUTH_MODE" envDefault:"token"`
+	// AdminToken is expected in X-Admin-Token header of admin requests.
+	// Required in token mode.
+	AdminToken string `env:"ADMIN_TOKEN"`
+	// JWT is set in jwt mode only.
+	JWT *JWTConfig
+}
+
+// JWTConfig describes how JWT are verified and mapped to identity.
+// Exactly one of JWKSFile and JWKSURL must be set.
+type JWTConfig struct {
+	JWKSFile string `env:"JWT_JWKS_FILE"`
+	JWKSURL  string `env:"JWT_JWKS_URL"`
+	// JWKSRefreshInterval is how often keys are refetched from JWKSURL.
+	JWKSRefreshInterval time.Duration `env:"JWT_JWKS_REFRESH_INTERVAL" envDefault:"1h"`
+	// Issuer and Audience are checked only if set.
+	Issuer   string        `env:"JWT_ISSUER"`
+	Audience string        `env:"JWT_AUDIENCE"`
+	Leeway   time.Duration `env:"JWT_LEEWAY" envDefault:"1m"`
+
+	UserClaim  string `env:"JWT_USER_CLAIM" envDefault:"sub"`
+	RolesClaim string `env:"JWT_ROLES_CLAIM" envDefault:"roles"`
+	// AdminRole in RolesClaim grants admin rights.
+	AdminRole string `env:"JWT_ADMIN_ROLE" envDefault:"admin"`
+}
+
+// WebhookConfig describes webhook receivers. Receiver is enabled only if its secret is set.
+type WebhookConfig struct {
+	// GitHubSecret is secret of GitHub webhook used to sign deliveries.
+	GitHubSecret string `env:"WEBHOOK_GITHUB_SECRET"`
+	// GitLabToken is secret token of GitLab webhook sent in X-Gitlab-Token header.
+	GitLabToken string `env:"WEBHOOK_GITLAB_TOKEN"`
+}
+
+// NotifyConfig describes delivery of notifications about review assignments.
+// Notifications are sent only if WebhookURLs or ChatWebhookURL are set.
+type NotifyConfig struct {
+	// WebhookURLs receive notifications in POST requests, e.g. "https://a.example/hook,https://b.example/hook".
+	WebhookURLs []string `env:"NOTIFY_WEBHOOK_URLS" envSeparator:","`
+	// WebhookSecret signs notifications. Required if WebhookURLs are set.
+	WebhookSecret string `env:"NOTIFY_WEBHOOK_SECRET"`
+	// Timeout limits single delivery attempt.
+	Timeout time.Duration `env:"NOTIFY_TIMEOUT" envDefault:"10s"`
+	// ChatWebhookURL is incoming webhook of Slack or Mattermost channel which receives
+	// messages mentioning assigned reviewers. Chat messages are sent only if it is set.
+	ChatWebhookURL string `env:"NOTIFY_CHAT_WEBHOOK_URL"`
+	// ChatFormat is ChatFormatSlack or ChatFormatMattermost.
+	ChatFormat string `env:"NOTIFY_CHAT_FORMAT" envDefault:"slack"`
+}
+
+// Chat formats selectable with NOTIFY_CHAT_FORMAT.
+const (
+	// ChatFormatSlack mentions users by member ids, e.g. <@U024BE7LH>.
+	ChatFormatSlack = "slack"
+	// ChatFormatMattermost mentions users by usernames, e.g. @alice.
+	ChatFormatMattermost = "mattermost"
+)
+
+// OutboxConfig describes how outbox messages are dispatched to sinks.
+type OutboxConfig struct {
+	// PollInterval is how often outbox is checked for due messages.
+	PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"1s"`
+	// Lease is how long claimed messages are hidden from other instances.
+	// Messages not processed within lease are claimed again.
+	Lease time.Duration `env:"OUTBOX_LEASE" envDefault:"5m"`
+	// MaxAttempts is number of attempts after which message is considered failed.
+	MaxAttempts int `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10"`
+	// RetryBackoff is delay before second attempt, it doubles with every next attempt up to RetryMaxBackoff.
+	RetryBackoff    time.Duration `env:"OUTBOX_RETRY_BACKOFF" envDefault:"1s"`
+	RetryMaxBackoff time.Duration `env:"OUTBOX_RETRY_MAX_BACKOFF" envDefault:"1h"`
+}
+
+// DigestConfig describes daily email digests of pending reviews.
+// Digests are sent only if SMTPAddr is set.
+type DigestConfig struct {
+	// SMTPAddr is host:port of SMTP server, e.g. "localhost:1025".
+	SMTPAddr string `env:"DIGEST_SMTP_ADDR"`
+	// SMTPUsername and SMTPPassword enable PLAIN authentication,
+	// which is allowed over TLS or to localhost only.
+	SMTPUsername string `env:"DIGEST_SMTP_USERNAME"`
+	SMTPPassword string `env:"DIGEST_SMTP_PASSWORD"`
+	// From is sender address, e.g. "Review Assigner <noreply@example.com>". Required if SMTPAddr is set.
+	From string `env:"DIGEST_FROM"`
+	// SendAt is time of day in UTC when digests are sent.
+	SendAt TimeOfDay `env:"DIGEST_SEND_AT" envDefault:"09:00"`
+	// Timeout limits sending of single digest.
+	Timeout time.Duration `env:"DIGEST_TIMEOUT" envDefault:"30s"`
+}
+
+// SLAConfig describes escalation of pull requests which exceeded review SLA of author's team.
+// SLA itself is set per team.
+type SLAConfig struct {
+	// ScanInterval is how often stale pull requests are searched for.
+	ScanInterval time.Duration `env:"SLA_SCAN_INTERVAL" envDefault:"1m"`
+}
+
+// SelectionConfig describes how reviewers are selected for pull requests.
+type SelectionConfig struct {
+	// Strategy is used for teams that are not listed in TeamStrategies.
+	Strategy string `env:"SELECTION_STRATEGY" envDefault:"random"`
+	// TeamStrategies overrides Strategy per team, e.g. "backend=round-robin,payments=weighted".
+	TeamStrategies StringMap `env:"SELECTION_TEAM_STRATEGIES"`
+	// ReviewerWeights is used by weighted strategy, e.g. "u1=3,u2=0.5".
+	// Users without weight have weight of 1.
+	ReviewerWeights FloatMap `env:"SELECTION_REVIEWER_WEIGHTS"`
+	// FallbackTeams maps team to sibling team which reviews its pull requests
+	// when the team is deactivated, e.g. "backend=platform".
+	FallbackTeams StringMap `env:"SELECTION_FALLBACK_TEAMS"`
 }
 
 func Load() (Config, error) {
@@ -30,11 +180,254 @@ func Load() (Config, error) {
 		return Config{}, fmt.Errorf("failed to parse base config: %w", err)
 	}
 
+	switch cfg.DBDriver {
+	case DriverPostgres:
+		dbCfg, err := LoadDB()
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.DB = &dbCfg
+	case DriverSQLite:
+		var sqliteCfg SQLiteConfig
+		if err := env.Parse(&sqliteCfg); err != nil {
+			return Config{}, fmt.Errorf("failed to parse SQLite config: %w", err)
+		}
+		cfg.SQLite = &sqliteCfg
+	default:
+		return Config{}, fmt.Errorf("unknown DB driver %q", cfg.DBDriver)
+	}
+
+	selectionCfg, err := loadSelection()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Selection = &selectionCfg
+
+	authCfg, err := loadAuth()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Auth = &authCfg
+
+	var webhookCfg WebhookConfig
+	if err := env.Parse(&webhookCfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse webhook config: %w", err)
+	}
+	cfg.Webhook = &webhookCfg
+
+	notifyCfg, err := loadNotify()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Notify = &notifyCfg
+
+	outboxCfg, err := loadOutbox()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Outbox = &outboxCfg
+
+	digestCfg, err := loadDigest()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Digest = &digestCfg
+
+	slaCfg, err := loadSLA()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.SLA = &slaCfg
+
+	return cfg, nil
+}
+
+// LoadDB parses postgres config only, e.g. for running migrations.
+func LoadDB() (DBConfig, error) {
 	var dbCfg DBConfig
 	if err := env.Parse(&dbCfg); err != nil {
-		return Config{}, fmt.Errorf("failed to parse DB config: %w", err)
+		return DBConfig{}, fmt.Errorf("failed to parse DB config: %w", err)
+	}
+	return dbCfg, nil
+}
+
+func loadSelection() (SelectionConfig, error) {
+	var selectionCfg SelectionConfig
+	if err := env.Parse(&selectionCfg); err != nil {
+		return SelectionConfig{}, fmt.Errorf("failed to parse selection config: %w", err)
 	}
-	cfg.DB = &dbCfg
 
-	return cfg, nil
+	for userID, weight := range selectionCfg.ReviewerWeights {
+		if math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 {
+			return SelectionConfig{}, fmt.Errorf("SELECTION_REVIEWER_WEIGHTS: weight of %s must be finite and non-negative", userID)
+		}
+	}
+
+	return selectionCfg, nil
+}
+
+func loadAuth() (AuthConfig, error) {
+	var authCfg AuthConfig
+	if err := env.Parse(&authCfg); err != nil {
+		return AuthConfig{}, fmt.Errorf("failed to parse auth config: %w", err)
+	}
+
+	switch authCfg.Mode {
+	case AuthModeToken:
+		if authCfg.AdminToken == "" {
+			return AuthConfig{}, errors.New("ADMIN_TOKEN is required in token auth mode")
+		}
+	case AuthModeJWT:
+		var jwtCfg JWTConfig
+		if err := env.Parse(&jwtCfg); err != nil {
+			return AuthConfig{}, fmt.Errorf("failed to parse JWT config: %w", err)
+		}
+		if (jwtCfg.JWKSFile == "") == (jwtCfg.JWKSURL == "") {
+			return AuthConfig{}, errors.New("exactly one of JWT_JWKS_FILE and JWT_JWKS_URL is required in jwt auth mode")
+		}
+		if jwtCfg.JWKSRefreshInterval <= 0 {
+			return AuthConfig{}, errors.New("JWT_JWKS_REFRESH_INTERVAL must be positive")
+		}
+		authCfg.JWT = &jwtCfg
+	default:
+		return AuthConfig{}, fmt.Errorf("unknown auth mode %q", authCfg.Mode)
+	}
+
+	return authCfg, nil
+}
+
+func loadNotify() (NotifyConfig, error) {
+	var notifyCfg NotifyConfig
+	if err := env.Parse(&notifyCfg); err != nil {
+		return NotifyConfig{}, fmt.Errorf("failed to parse notify config: %w", err)
+	}
+
+	if len(notifyCfg.WebhookURLs) > 0 && notifyCfg.WebhookSecret == "" {
+		return NotifyConfig{}, errors.New("NOTIFY_WEBHOOK_SECRET is required if NOTIFY_WEBHOOK_URLS are set")
+	}
+	if notifyCfg.ChatFormat != ChatFormatSlack && notifyCfg.ChatFormat != ChatFormatMattermost {
+		return NotifyConfig{}, fmt.Errorf("unknown chat format %q", notifyCfg.ChatFormat)
+	}
+
+	return notifyCfg, nil
+}
+
+func loadOutbox() (OutboxConfig, error) {
+	var outboxCfg OutboxConfig
+	if err := env.Parse(&outboxCfg); err != nil {
+		return OutboxConfig{}, fmt.Errorf("failed to parse outbox config: %w", err)
+	}
+
+	if outboxCfg.MaxAttempts < 1 {
+		return OutboxConfig{}, errors.New("OUTBOX_MAX_ATTEMPTS must be positive")
+	}
+	if outboxCfg.Lease <= 0 {
+		return OutboxConfig{}, errors.New("OUTBOX_LEASE must be positive")
+	}
+	if outboxCfg.PollInterval <= 0 {
+		return OutboxConfig{}, errors.New("OUTBOX_POLL_INTERVAL must be positive")
+	}
+	if outboxCfg.RetryBackoff <= 0 {
+		return OutboxConfig{}, errors.New("OUTBOX_RETRY_BACKOFF must be positive")
+	}
+	if outboxCfg.RetryMaxBackoff < outboxCfg.RetryBackoff {
+		return OutboxConfig{}, errors.New("OUTBOX_RETRY_MAX_BACKOFF must not be less than OUTBOX_RETRY_BACKOFF")
+	}
+
+	return outboxCfg, nil
+}
+
+func loadDigest() (DigestConfig, error) {
+	var digestCfg DigestConfig
+	if err := env.Parse(&digestCfg); err != nil {
+		return DigestConfig{}, fmt.Errorf("failed to parse digest config: %w", err)
+	}
+
+	if digestCfg.SMTPAddr != "" {
+		if digestCfg.From == "" {
+			return DigestConfig{}, errors.New("DIGEST_FROM is required if DIGEST_SMTP_ADDR is set")
+		}
+		if _, err := mail.ParseAddress(digestCfg.From); err != nil {
+			return DigestConfig{}, fmt.Errorf("invalid DIGEST_FROM: %w", err)
+		}
+		if _, _, err := net.SplitHostPort(digestCfg.SMTPAddr); err != nil {
+			return DigestConfig{}, fmt.Errorf("invalid DIGEST_SMTP_ADDR: %w", err)
+		}
+	}
+
+	return digestCfg, nil
+}
+
+func loadSLA() (SLAConfig, error) {
+	var slaCfg SLAConfig
+	if err := env.Parse(&slaCfg); err != nil {
+		return SLAConfig{}, fmt.Errorf("failed to parse sla config: %w", err)
+	}
+
+	if slaCfg.ScanInterval <= 0 {
+		return SLAConfig{}, errors.New("SLA_SCAN_INTERVAL must be positive")
+	}
+
+	return slaCfg, nil
+}
+
+// StringMap is parsed from "key=value,key=value" string.
+type StringMap map[string]string
+
+func (m *StringMap) UnmarshalText(text []byte) error {
+	pairs, err := splitPairs(string(text))
+	if err != nil {
+		return err
+	}
+	*m = pairs
+	return nil
+}
+
+// FloatMap is parsed from "key=number,key=number" string.
+type FloatMap map[string]float64
+
+func (m *FloatMap) UnmarshalText(text []byte) error {
+	pairs, err := splitPairs(string(text))
+	if err != nil {
+		return err
+	}
+
+	result := make(FloatMap, len(pairs))
+	for k, v := range pairs {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number for %q: %w", k, err)
+		}
+		result[k] = f
+	}
+	*m = result
+	return nil
+}
+
+// TimeOfDay is parsed from "15:04" string, it is duration since midnight.
+type TimeOfDay time.Duration
+
+func (t *TimeOfDay) UnmarshalText(text []byte) error {
+	parsed, err := time.Parse("15:04", string(text))
+	if err != nil {
+		return fmt.Errorf("invalid time of day %q, want HH:MM", text)
+	}
+	*t = TimeOfDay(time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute)
+	return nil
+}
+
+func splitPairs(s string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid key=value pair %q", pair)
+		}
+		result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return result, nil
 }
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadOutbox(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "equal backoffs", env: map[string]string{"OUTBOX_RETRY_BACKOFF": "1m", "OUTBOX_RETRY_MAX_BACKOFF": "1m"}},
+		{name: "zero poll interval", env: map[string]string{"OUTBOX_POLL_INTERVAL": "0s"}, wantErr: true},
+		{name: "negative poll interval", env: map[string]string{"OUTBOX_POLL_INTERVAL": "-1s"}, wantErr: true},
+		{name: "zero lease", env: map[string]string{"OUTBOX_LEASE": "0s"}, wantErr: true},
+		{name: "zero max attempts", env: map[string]string{"OUTBOX_MAX_ATTEMPTS": "0"}, wantErr: true},
+		{name: "zero backoff", env: map[string]string{"OUTBOX_RETRY_BACKOFF": "0s"}, wantErr: true},
+		{name: "zero max backoff", env: map[string]string{"OUTBOX_RETRY_MAX_BACKOFF": "0s"}, wantErr: true},
+		{
+			name:    "max backoff less than backoff",
+			env:     map[string]string{"OUTBOX_RETRY_BACKOFF": "1m", "OUTBOX_RETRY_MAX_BACKOFF": "30s"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := loadOutbox()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got config %+v", cfg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadOutbox: %v", err)
+			}
+			if cfg.PollInterval <= 0 || cfg.RetryBackoff <= 0 || cfg.RetryMaxBackoff < cfg.RetryBackoff {
+				t.Fatalf("invalid config loaded: %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestLoadOutboxDefaults(t *testing.T) {
+	cfg, err := loadOutbox()
+	if err != nil {
+		t.Fatalf("loadOutbox: %v", err)
+	}
+
+	want := OutboxConfig{
+		PollInterval:    time.Second,
+		Lease:           5 * time.Minute,
+		MaxAttempts:     10,
+		RetryBackoff:    time.Second,
+		RetryMaxBackoff: time.Hour,
+	}
+	if cfg != want {
+		t.Fatalf("want %+v, got %+v", want, cfg)
+	}
+}
+
+func TestLoadSelectionWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights string
+		wantErr bool
+	}{
+		{name: "valid", weights: "u1=3,u2=0.5,u3=0"},
+		{name: "negative", weights: "u1=-1", wantErr: true},
+		{name: "nan", weights: "u1=NaN", wantErr: true},
+		{name: "inf", weights: "u1=+Inf", wantErr: true},
+		{name: "not a number", weights: "u1=heavy", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SELECTION_REVIEWER_WEIGHTS", tt.weights)
+
+			cfg, err := loadSelection()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got config %+v", cfg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadSelection: %v", err)
+			}
+			if len(cfg.ReviewerWeights) != 3 || cfg.ReviewerWeights["u2"] != 0.5 {
+				t.Fatalf("unexpected weights: %v", cfg.ReviewerWeights)
+			}
+		})
+	}
+}
+
+func TestLoadAuthJWT(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "jwks url", env: map[string]string{"JWT_JWKS_URL": "https://sso.example.com/jwks"}},
+		{name: "jwks file", env: map[string]string{"JWT_JWKS_FILE": "jwks.json"}},
+		{name: "no jwks", wantErr: true},
+		{
+			name:    "both jwks",
+			env:     map[string]string{"JWT_JWKS_URL": "https://sso.example.com/jwks", "JWT_JWKS_FILE": "jwks.json"},
+			wantErr: true,
+		},
+		{
+			name:    "zero refresh interval",
+			env:     map[string]string{"JWT_JWKS_URL": "https://sso.example.com/jwks", "JWT_JWKS_REFRESH_INTERVAL": "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "negative refresh interval",
+			env:     map[string]string{"JWT_JWKS_URL": "https://sso.example.com/jwks", "JWT_JWKS_REFRESH_INTERVAL": "-1m"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AUTH_MODE", AuthModeJWT)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := loadAuth()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got config %+v", cfg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadAuth: %v", err)
+			}
+			if cfg.JWT == nil || cfg.JWT.JWKSRefreshInterval != time.Hour {
+				t.Fatalf("invalid config loaded: %+v", cfg)
+			}
+		})
+	}
+}
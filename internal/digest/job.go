@@ -0,0 +1,107 @@
+// Package digest emails users daily digests of pull requests waiting for their review.
+package digest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/service"
+)
+
+// Job sends digests every day at configured time. Every digest is claimed in storage
+// before it is sent, so concurrent instances send it once. Digest which failed to send
+// is not retried until next day.
+type Job struct {
+	service *service.Service
+	mailer  *SMTPMailer
+	from    *mail.Address
+	sendAt  time.Duration
+}
+
+func NewJob(service *service.Service, cfg config.DigestConfig) (*Job, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	mailer, err := NewSMTPMailer(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Job{service: service, mailer: mailer, from: from, sendAt: time.Duration(cfg.SendAt)}, nil
+}
+
+// Run sends digests at scheduled time until ctx is done. Digests missed while service was down are not sent.
+func (j *Job) Run(ctx context.Context) {
+	for {
+		scheduled := j.next(time.Now())
+		timer := time.NewTimer(time.Until(scheduled))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := j.SendDigests(ctx, scheduled); err != nil && ctx.Err() == nil {
+			slog.Error("failed to send digests", "error", err)
+		}
+	}
+}
+
+// SendDigests sends digests which were not sent since scheduled time.
+// Failure to claim or send single digest is logged and doesn't stop others.
+func (j *Job) SendDigests(ctx context.Context, scheduled time.Time) error {
+	ctx = service.WithSystemIdentity(ctx, service.SystemActor)
+	now := time.Now()
+	digests, err := j.service.GetDigests(ctx, now)
+	if err != nil {
+		return fmt.Errorf("service failed to get digests: %w", err)
+	}
+
+	sent := 0
+	for _, digest := range digests {
+		claimed, err := j.service.ClaimDigest(ctx, digest.User.Id, now, scheduled)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.Error("failed to claim digest", "user_id", digest.User.Id, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		to := &mail.Address{Name: digest.User.Username, Address: digest.User.Email}
+		msg, err := buildMessage(j.from, to, digest)
+		if err == nil {
+			err = j.mailer.Send(ctx, j.from, to, msg)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.Error("failed to send digest", "user_id", digest.User.Id, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	slog.Info("digests sent", "sent", sent, "digests", len(digests))
+	return nil
+}
+
+// next returns the first scheduled time after now.
+func (j *Job) next(now time.Time) time.Time {
+	// zero time is midnight in UTC, so days are truncated in UTC
+	scheduled := now.Truncate(24 * time.Hour).Add(j.sendAt)
+	if !scheduled.After(now) {
+		scheduled = scheduled.Add(24 * time.Hour)
+	}
+	return scheduled
+}
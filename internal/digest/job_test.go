@@ -0,0 +1,234 @@
+package digest_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/digest"
+	"review-assigner/internal/model"
+	"review-assigner/internal/service"
+	"review-assigner/internal/storage/memory"
+)
+
+// newDigestService creates team where author u1 requests review of every other member:
+// u2 is subscribed, u3 opted out and u4 has no email.
+func newDigestService(t *testing.T) *service.Service {
+	t.Helper()
+
+	ctx := service.WithIdentity(context.Background(), service.Identity{Admin: true})
+	svc, err := service.NewService(memory.New(), config.SelectionConfig{Strategy: "random"}, nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+
+	_, err = svc.AddTeamAddUpdateUsers(ctx, &model.Team{
+		Name:              "backend",
+		RequiredReviewers: 3,
+		Members: []model.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true, Email: "alice@example.com"},
+			{UserID: "u2", Username: "Bob", IsActive: true, Email: "bob@example.com"},
+			{UserID: "u3", Username: "Carol", IsActive: true, Email: "carol@example.com"},
+			{UserID: "u4", Username: "Dave", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddTeamAddUpdateUsers: %v", err)
+	}
+	if err := svc.SetDigestOptOut(ctx, "u3", true); err != nil {
+		t.Fatalf("SetDigestOptOut: %v", err)
+	}
+
+	for _, pr := range []model.PullRequestShort{
+		{Id: "pr-1", Name: "Add login", AuthorID: "u1"},
+		{Id: "pr-2", Name: "Fix <script> & retries", AuthorID: "u1"},
+		{Id: "pr-3", Name: "Merged already", AuthorID: "u1"},
+	} {
+		if _, err := svc.CreatePullRequest(ctx, &pr); err != nil {
+			t.Fatalf("CreatePullRequest: %v", err)
+		}
+	}
+	if _, err := svc.MergePullRequest(ctx, "pr-3"); err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+
+	return svc
+}
+
+func newJob(t *testing.T, svc *service.Service, smtpAddr string) *digest.Job {
+	t.Helper()
+
+	job, err := digest.NewJob(svc, config.DigestConfig{
+		SMTPAddr: smtpAddr,
+		From:     "Review Assigner <noreply@example.com>",
+		Timeout:  5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewJob: %v", err)
+	}
+	return job
+}
+
+// readParts returns decoded bodies of multipart message by content type.
+func readParts(t *testing.T, msg *mail.Message) map[string]string {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("want multipart/alternative message, got %q (%v)", msg.Header.Get("Content-Type"), err)
+	}
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		// quoted-printable parts are decoded by reader
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("failed to read part body: %v", err)
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(body)
+	}
+	return parts
+}
+
+// readLines splits rendered body into lines.
+func readLines(t *testing.T, body string) []string {
+	t.Helper()
+
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+func TestSendDigests(t *testing.T) {
+	stub := newSMTPStub(t)
+	svc := newDigestService(t)
+	job := newJob(t, svc, stub.Addr())
+	scheduled := time.Now().Add(-time.Minute)
+
+	if err := job.SendDigests(context.Background(), scheduled); err != nil {
+		t.Fatalf("SendDigests: %v", err)
+	}
+
+	// only u2 gets digest: u3 opted out, u4 has no email and u1 reviews nothing
+	sent := stub.Sent()
+	if len(sent) != 1 {
+		t.Fatalf("want 1 digest, got %d", len(sent))
+	}
+	if sent[0].from != "noreply@example.com" || !slices.Equal(sent[0].to, []string{"bob@example.com"}) {
+		t.Fatalf("unexpected envelope: from %q to %v", sent[0].from, sent[0].to)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(sent[0].data)))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	if got, want := msg.Header.Get("To"), `"Bob" <bob@example.com>`; got != want {
+		t.Fatalf("want To %q, got %q", want, got)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "2 pull request(s) waiting for your review" {
+		t.Fatalf("unexpected subject %q (%v)", subject, err)
+	}
+
+	parts := readParts(t, msg)
+	if len(parts) != 2 {
+		t.Fatalf("want plain text and HTML parts, got %v", parts)
+	}
+
+	text := parts["text/plain"]
+	lines := readLines(t, text)
+	if len(lines) == 0 || lines[0] != "Hi Bob," {
+		t.Fatalf("unexpected greeting in %q", text)
+	}
+	for _, want := range []string{
+		"* Add login (pr-1) by u1, opened ",
+		"* Fix <script> & retries (pr-2) by u1, opened ",
+	} {
+		if !slices.ContainsFunc(lines, func(line string) bool { return strings.HasPrefix(line, want) }) {
+			t.Fatalf("want line %q in plain text body %q", want, text)
+		}
+	}
+
+	html := parts["text/html"]
+	for _, want := range []string{
+		"<p>Hi Bob,</p>",
+		"<td>Add login <code>pr-1</code></td>",
+		"<td>Fix &lt;script&gt; &amp; retries <code>pr-2</code></td>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("want %q in HTML body %q", want, html)
+		}
+	}
+
+	for _, body := range []string{text, html} {
+		if strings.Contains(body, "pr-3") {
+			t.Fatalf("merged pull request is in digest: %q", body)
+		}
+	}
+}
+
+func TestSendDigestsOnce(t *testing.T) {
+	stub := newSMTPStub(t)
+	svc := newDigestService(t)
+	scheduled := time.Now().Add(-time.Minute)
+
+	// second instance sends no digests claimed by the first one
+	for _, job := range []*digest.Job{newJob(t, svc, stub.Addr()), newJob(t, svc, stub.Addr())} {
+		if err := job.SendDigests(context.Background(), scheduled); err != nil {
+			t.Fatalf("SendDigests: %v", err)
+		}
+	}
+	if sent := stub.Sent(); len(sent) != 1 {
+		t.Fatalf("want 1 digest, got %d", len(sent))
+	}
+
+	// digest is sent again at next scheduled time
+	if err := newJob(t, svc, stub.Addr()).SendDigests(context.Background(), time.Now()); err != nil {
+		t.Fatalf("SendDigests: %v", err)
+	}
+	if sent := stub.Sent(); len(sent) != 2 {
+		t.Fatalf("want 2 digests, got %d", len(sent))
+	}
+}
+
+func TestSendDigestsRejected(t *testing.T) {
+	stub := newSMTPStub(t, "bob@example.com")
+	svc := newDigestService(t)
+	scheduled := time.Now().Add(-time.Minute)
+
+	// failed digest is logged, not returned, and not retried until next scheduled time
+	if err := newJob(t, svc, stub.Addr()).SendDigests(context.Background(), scheduled); err != nil {
+		t.Fatalf("SendDigests: %v", err)
+	}
+	admin := service.WithIdentity(context.Background(), service.Identity{Admin: true})
+	claimed, err := svc.ClaimDigest(admin, "u2", time.Now(), scheduled)
+	if err != nil {
+		t.Fatalf("ClaimDigest: %v", err)
+	}
+	if claimed {
+		t.Fatal("failed digest was not claimed")
+	}
+	if sent := stub.Sent(); len(sent) != 0 {
+		t.Fatalf("want no digests, got %d", len(sent))
+	}
+}
@@ -0,0 +1,190 @@
+package digest_test
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"review-assigner/internal/digest"
+)
+
+// sentMail is message accepted by smtpStub.
+type sentMail struct {
+	from string
+	to   []string
+	data []byte
+}
+
+// smtpStub is in-process SMTP server which accepts every message, except to rejected recipients,
+// and records it. It supports neither STARTTLS nor authentication.
+type smtpStub struct {
+	listener net.Listener
+	rejected []string
+
+	mu   sync.Mutex
+	sent []sentMail
+	wg   sync.WaitGroup
+}
+
+func newSMTPStub(t *testing.T, rejected ...string) *smtpStub {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	stub := &smtpStub{listener: listener, rejected: rejected}
+	stub.wg.Add(1)
+	go stub.serve()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		stub.wg.Wait()
+	})
+
+	return stub
+}
+
+func (s *smtpStub) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Sent returns messages accepted so far.
+func (s *smtpStub) Sent() []sentMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sentMail(nil), s.sent...)
+}
+
+func (s *smtpStub) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(textproto.NewConn(conn))
+		}()
+	}
+}
+
+func (s *smtpStub) handle(conn *textproto.Conn) {
+	var current sentMail
+	reply := func(line string) bool {
+		return conn.PrintfLine("%s", line) == nil
+	}
+
+	if !reply("220 localhost ESMTP stub") {
+		return
+	}
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			current = sentMail{from: addrArg(arg)}
+			reply("250 OK")
+		case "RCPT":
+			to := addrArg(arg)
+			for _, rejected := range s.rejected {
+				if to == rejected {
+					reply("550 no such user")
+					to = ""
+				}
+			}
+			if to != "" {
+				current.to = append(current.to, to)
+				reply("250 OK")
+			}
+		case "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.data = data
+			s.mu.Lock()
+			s.sent = append(s.sent, current)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+// addrArg returns address of MAIL FROM or RCPT TO argument, e.g. "FROM:<a@example.com>".
+func addrArg(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	return strings.Trim(addr, "<> ")
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	stub := newSMTPStub(t)
+	mailer, err := digest.NewSMTPMailer(stub.Addr(), "", "", 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewSMTPMailer: %v", err)
+	}
+
+	from := &mail.Address{Name: "Review Assigner", Address: "noreply@example.com"}
+	to := &mail.Address{Name: "Bob", Address: "bob@example.com"}
+	msg := "Subject: test\r\n\r\nhello\r\n.leading dot\r\n"
+	if err := mailer.Send(context.Background(), from, to, []byte(msg)); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	sent := stub.Sent()
+	if len(sent) != 1 {
+		t.Fatalf("want 1 message, got %d", len(sent))
+	}
+	if sent[0].from != from.Address || len(sent[0].to) != 1 || sent[0].to[0] != to.Address {
+		t.Fatalf("unexpected envelope: from %q to %v", sent[0].from, sent[0].to)
+	}
+	// data is read back with dot stuffing removed and lines ending with \n
+	if got, want := string(sent[0].data), "Subject: test\n\nhello\n.leading dot\n"; got != want {
+		t.Fatalf("want data %q, got %q", want, got)
+	}
+}
+
+func TestSMTPMailerSendRejectedRecipient(t *testing.T) {
+	stub := newSMTPStub(t, "bob@example.com")
+	mailer, err := digest.NewSMTPMailer(stub.Addr(), "", "", 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewSMTPMailer: %v", err)
+	}
+
+	from := &mail.Address{Address: "noreply@example.com"}
+	to := &mail.Address{Address: "bob@example.com"}
+	if err := mailer.Send(context.Background(), from, to, []byte("Subject: test\r\n\r\nhello\r\n")); err == nil {
+		t.Fatal("want error for rejected recipient, got nil")
+	}
+	if sent := stub.Sent(); len(sent) != 0 {
+		t.Fatalf("want no messages, got %d", len(sent))
+	}
+}
+
+func TestNewSMTPMailerInvalidAddr(t *testing.T) {
+	if _, err := digest.NewSMTPMailer("localhost", "", "", time.Second); err == nil {
+		t.Fatal("want error for address without port, got nil")
+	}
+}
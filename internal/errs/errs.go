@@ -27,3 +27,26 @@ type PullRequestExistsError struct {
 func (e PullRequestExistsError) Error() string {
 	return fmt.Sprintf("%s already exists", e.PullRequestID)
 }
+
+type ReviewAssignmentExistsError struct {
+	PullRequestID string
+	UserID        string
+}
+
+func (e ReviewAssignmentExistsError) Error() string {
+	return fmt.Sprintf("%s is already assigned to %s", e.UserID, e.PullRequestID)
+}
+
+// ForbiddenError is returned when caller is not allowed to perform operation.
+// UserID is empty for callers without identity.
+type ForbiddenError struct {
+	UserID string
+	Action string
+}
+
+func (e ForbiddenError) Error() string {
+	if e.UserID == "" {
+		return fmt.Sprintf("not allowed to %s", e.Action)
+	}
+	return fmt.Sprintf("%s is not allowed to %s", e.UserID, e.Action)
+}
@@ -0,0 +1,246 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// KeySource provides public keys tokens are verified with.
+type KeySource interface {
+	// Keys returns keys matching kid. Empty kid matches all keys.
+	Keys(ctx context.Context, kid string) ([]crypto.PublicKey, error)
+}
+
+// KeySet is a parsed JSON Web Key Set.
+type KeySet struct {
+	keys []key
+}
+
+type key struct {
+	kid    string
+	public crypto.PublicKey
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseKeySet parses JSON Web Key Set. Keys which are not meant for signatures
+// or have unsupported type are skipped.
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var raw struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	set := &KeySet{}
+	for _, jwk := range raw.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		public, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWK %q: %w", jwk.Kid, err)
+		}
+		if public == nil {
+			slog.Warn("skipping JWK of unsupported type", "kid", jwk.Kid, "kty", jwk.Kty)
+			continue
+		}
+		set.keys = append(set.keys, key{kid: jwk.Kid, public: public})
+	}
+	if len(set.keys) == 0 {
+		return nil, errors.New("JWKS contains no signature keys")
+	}
+
+	return set, nil
+}
+
+// LoadKeySetFile reads JSON Web Key Set from file.
+func LoadKeySetFile(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	return ParseKeySet(data)
+}
+
+func (s *KeySet) Keys(_ context.Context, kid string) ([]crypto.PublicKey, error) {
+	var result []crypto.PublicKey
+	for _, k := range s.keys {
+		if kid == "" || k.kid == kid {
+			result = append(result, k.public)
+		}
+	}
+	return result, nil
+}
+
+// publicKey returns nil key if key type is not supported.
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var (
+			curve     elliptic.Curve
+			ecdhCurve ecdh.Curve
+		)
+		switch k.Crv {
+		case "P-256":
+			curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y: %w", err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid coordinates length")
+		}
+		// ecdh validates that point is on curve
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid point: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// RemoteKeySet fetches JSON Web Key Set from URL, e.g. of OIDC provider.
+// Keys are refetched when refreshInterval passes or token is signed with unknown key,
+// but not more often than once in minRefreshInterval. If refetch fails, last fetched keys are used.
+// Until keys are fetched for the first time, every call tries to fetch them.
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+
+	mu          sync.Mutex
+	set         *KeySet
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+const minRefreshInterval = 10 * time.Second
+
+func NewRemoteKeySet(url string, refreshInterval time.Duration) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:             url,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		refreshInterval: refreshInterval,
+	}
+}
+
+func (s *RemoteKeySet) Keys(ctx context.Context, kid string) ([]crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.set == nil || time.Since(s.fetchedAt) > s.refreshInterval && time.Since(s.attemptedAt) > minRefreshInterval {
+		if err := s.refresh(ctx); err != nil {
+			if s.set == nil {
+				return nil, err
+			}
+			slog.Warn("failed to refresh JWKS, using last fetched keys", "error", err)
+		}
+	}
+
+	keys, _ := s.set.Keys(ctx, kid)
+	if len(keys) == 0 && time.Since(s.attemptedAt) > minRefreshInterval {
+		// keys may have been rotated
+		if err := s.refresh(ctx); err != nil {
+			return nil, err
+		}
+		keys, _ = s.set.Keys(ctx, kid)
+	}
+
+	return keys, nil
+}
+
+// refresh replaces keys with fetched ones. Keys are kept if fetch fails.
+func (s *RemoteKeySet) refresh(ctx context.Context) error {
+	s.attemptedAt = time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	set, err := ParseKeySet(data)
+	if err != nil {
+		return err
+	}
+	s.set = set
+	s.fetchedAt = time.Now()
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package jwt
+
+import (
+	"context"
+	"crypto/elliptic"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseKeySet(t *testing.T) {
+	key := newECKey(t, "ec", elliptic.P256())
+
+	set, err := ParseKeySet([]byte(`{"keys": [
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQAB", "e": "AQAB"},
+		{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"}
+	]}`))
+	if err == nil {
+		t.Fatalf("want error for JWKS without signature keys, got %+v", set)
+	}
+
+	set = keySet(t, key)
+	keys, _ := set.Keys(context.Background(), "ec")
+	if len(keys) != 1 {
+		t.Fatalf("want key ec, got %v", keys)
+	}
+	keys, _ = set.Keys(context.Background(), "other")
+	if len(keys) != 0 {
+		t.Fatalf("want no keys for unknown kid, got %v", keys)
+	}
+
+	// point is not on curve
+	invalid := []byte(`{"keys": [{"kty": "EC", "kid": "ec", "crv": "P-256",
+		"x": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", "y": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"}]}`)
+	if _, err := ParseKeySet(invalid); err == nil {
+		t.Fatalf("want error for point not on curve")
+	}
+}
+
+// jwksServer serves JWKS which can be replaced or broken during test.
+type jwksServer struct {
+	mu       sync.Mutex
+	jwks     []byte
+	broken   bool
+	requests int
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if s.broken {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = w.Write(s.jwks)
+}
+
+func (s *jwksServer) set(jwks []byte, broken bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jwks, s.broken = jwks, broken
+}
+
+func (s *jwksServer) requireRequests(t *testing.T, want int) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.requests != want {
+		t.Fatalf("want %d JWKS requests, got %d", want, s.requests)
+	}
+}
+
+func requireKeys(t *testing.T, set *RemoteKeySet, kid string, want int) {
+	t.Helper()
+
+	keys, err := set.Keys(context.Background(), kid)
+	if err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	if len(keys) != want {
+		t.Fatalf("want %d keys of kid %q, got %v", want, kid, keys)
+	}
+}
+
+// expire makes set look like it was fetched and refreshed more than d ago.
+func expire(set *RemoteKeySet, d time.Duration) {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	set.fetchedAt = set.fetchedAt.Add(-d)
+	set.attemptedAt = set.attemptedAt.Add(-d)
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	oldKey := newECKey(t, "old", elliptic.P256())
+	newKey := newECKey(t, "new", elliptic.P256())
+
+	handler := &jwksServer{}
+	handler.set(jwks(t, oldKey), true)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	set := NewRemoteKeySet(server.URL, time.Hour)
+
+	// nothing to serve until keys are fetched
+	if _, err := set.Keys(context.Background(), "old"); err == nil {
+		t.Fatalf("want error while JWKS is unavailable")
+	}
+	handler.set(jwks(t, oldKey), false)
+	requireKeys(t, set, "old", 1)
+	handler.requireRequests(t, 2)
+
+	// keys are cached
+	requireKeys(t, set, "old", 1)
+	handler.requireRequests(t, 2)
+
+	// unknown kid doesn't cause refetch right after fetch
+	handler.set(jwks(t, oldKey, newKey), false)
+	requireKeys(t, set, "new", 0)
+	handler.requireRequests(t, 2)
+
+	// keys are rotated
+	expire(set, minRefreshInterval)
+	requireKeys(t, set, "new", 1)
+	handler.requireRequests(t, 3)
+
+	// last fetched keys are used when refresh fails
+	handler.set(nil, true)
+	expire(set, 2*time.Hour)
+	requireKeys(t, set, "old", 1)
+	requireKeys(t, set, "new", 1)
+	handler.requireRequests(t, 4)
+
+	// failed refresh is not retried on every call
+	requireKeys(t, set, "old", 1)
+	handler.requireRequests(t, 4)
+
+	// keys are refreshed after server recovers
+	handler.set(jwks(t, newKey), false)
+	expire(set, 2*time.Hour)
+	requireKeys(t, set, "old", 0)
+	requireKeys(t, set, "new", 1)
+	handler.requireRequests(t, 5)
+}
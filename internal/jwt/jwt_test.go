@@ -0,0 +1,323 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testKey is private key with its kid.
+type testKey struct {
+	kid     string
+	private crypto.Signer
+}
+
+func newRSAKey(t *testing.T, kid string) testKey {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	return testKey{kid: kid, private: private}
+}
+
+func newECKey(t *testing.T, kid string, curve elliptic.Curve) testKey {
+	t.Helper()
+
+	private, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	return testKey{kid: kid, private: private}
+}
+
+// jwks returns JSON Web Key Set of public parts of keys.
+func jwks(t *testing.T, keys ...testKey) []byte {
+	t.Helper()
+
+	encode := base64.RawURLEncoding.EncodeToString
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for _, k := range keys {
+		switch public := k.private.Public().(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, jsonWebKey{
+				Kty: "RSA",
+				Kid: k.kid,
+				Use: "sig",
+				N:   encode(public.N.Bytes()),
+				E:   encode(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			size := (public.Curve.Params().BitSize + 7) / 8
+			set.Keys = append(set.Keys, jsonWebKey{
+				Kty: "EC",
+				Kid: k.kid,
+				Crv: public.Curve.Params().Name,
+				X:   encode(public.X.FillBytes(make([]byte, size))),
+				Y:   encode(public.Y.FillBytes(make([]byte, size))),
+			})
+		}
+	}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+	return data
+}
+
+func keySet(t *testing.T, keys ...testKey) *KeySet {
+	t.Helper()
+
+	set, err := ParseKeySet(jwks(t, keys...))
+	if err != nil {
+		t.Fatalf("ParseKeySet: %v", err)
+	}
+	return set
+}
+
+// sign returns token with claims signed by key with alg.
+func sign(t *testing.T, key testKey, alg string, claims map[string]any) string {
+	t.Helper()
+
+	signed := encodeSegment(t, map[string]any{"alg": alg, "kid": key.kid, "typ": "JWT"}) + "." + encodeSegment(t, claims)
+
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	default:
+		hash = crypto.SHA512
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	var (
+		signature []byte
+		err       error
+	)
+	switch private := key.private.(type) {
+	case *rsa.PrivateKey:
+		if alg[:2] == "PS" {
+			signature, err = rsa.SignPSS(rand.Reader, private, hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, private, hash, digest)
+		}
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, private, digest)
+		if err == nil {
+			size := (private.Curve.Params().BitSize + 7) / 8
+			signature = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+		}
+	}
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal token segment: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// validClaims returns claims valid for an hour.
+func validClaims() map[string]any {
+	return map[string]any{"sub": "u1", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa")
+	keys := []testKey{
+		rsaKey,
+		newECKey(t, "p256", elliptic.P256()),
+		newECKey(t, "p384", elliptic.P384()),
+		newECKey(t, "p521", elliptic.P521()),
+	}
+	verifier := NewVerifier(keySet(t, keys...))
+
+	tests := []struct {
+		alg string
+		key testKey
+	}{
+		{alg: "RS256", key: rsaKey},
+		{alg: "RS384", key: rsaKey},
+		{alg: "RS512", key: rsaKey},
+		{alg: "PS256", key: rsaKey},
+		{alg: "PS384", key: rsaKey},
+		{alg: "PS512", key: rsaKey},
+		{alg: "ES256", key: keys[1]},
+		{alg: "ES384", key: keys[2]},
+		{alg: "ES512", key: keys[3]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), sign(t, tt.key, tt.alg, validClaims()))
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if claims.String("sub") != "u1" {
+				t.Fatalf("unexpected claims %v", claims)
+			}
+		})
+	}
+
+	// token without kid is checked against all keys
+	token := sign(t, testKey{kid: "", private: keys[2].private}, "ES384", validClaims())
+	if _, err := verifier.Verify(context.Background(), token); err != nil {
+		t.Fatalf("Verify without kid: %v", err)
+	}
+}
+
+func requireInvalid(t *testing.T, verifier *Verifier, token string) {
+	t.Helper()
+
+	claims, err := verifier.Verify(context.Background(), token)
+	if !errors.Is(err, InvalidTokenErr) {
+		t.Fatalf("want InvalidTokenErr, got claims %v and error %v", claims, err)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa")
+	ecKey := newECKey(t, "ec", elliptic.P256())
+	verifier := NewVerifier(keySet(t, rsaKey, ecKey))
+
+	t.Run("kid mismatch", func(t *testing.T) {
+		token := sign(t, testKey{kid: "other", private: rsaKey.private}, "RS256", validClaims())
+		requireInvalid(t, verifier, token)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		requireInvalid(t, verifier, sign(t, newRSAKey(t, "rsa"), "RS256", validClaims()))
+	})
+	t.Run("key of other type", func(t *testing.T) {
+		requireInvalid(t, verifier, sign(t, testKey{kid: "ec", private: rsaKey.private}, "RS256", validClaims()))
+	})
+	t.Run("curve mismatch", func(t *testing.T) {
+		requireInvalid(t, verifier, sign(t, newECKey(t, "ec", elliptic.P384()), "ES384", validClaims()))
+	})
+	t.Run("tampered claims", func(t *testing.T) {
+		parts := strings.Split(sign(t, rsaKey, "RS256", validClaims()), ".")
+		claims := validClaims()
+		claims["sub"] = "admin"
+		parts[1] = encodeSegment(t, claims)
+		requireInvalid(t, verifier, strings.Join(parts, "."))
+	})
+	t.Run("alg none", func(t *testing.T) {
+		token := encodeSegment(t, map[string]any{"alg": "none", "kid": "rsa"}) + "." + encodeSegment(t, validClaims()) + "."
+		requireInvalid(t, verifier, token)
+	})
+	t.Run("alg HS256", func(t *testing.T) {
+		// public key is known to everyone, so it must not be accepted as HMAC secret
+		secret := jwks(t, rsaKey)
+		signed := encodeSegment(t, map[string]any{"alg": "HS256", "kid": "rsa"}) + "." + encodeSegment(t, validClaims())
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signed))
+		requireInvalid(t, verifier, signed+"."+base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+	})
+	t.Run("malformed", func(t *testing.T) {
+		requireInvalid(t, verifier, "not a token")
+	})
+}
+
+func TestVerifyClaims(t *testing.T) {
+	key := newECKey(t, "ec", elliptic.P256())
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		valid   bool
+		prepare func(v *Verifier)
+	}{
+		{name: "missing exp", claims: map[string]any{"sub": "u1"}},
+		{name: "expired", claims: map[string]any{"exp": now.Add(-time.Minute).Unix()}},
+		{
+			name:    "expired within leeway",
+			claims:  map[string]any{"exp": now.Add(-time.Minute).Unix()},
+			prepare: func(v *Verifier) { v.Leeway = 2 * time.Minute },
+			valid:   true,
+		},
+		{name: "not valid yet", claims: map[string]any{"exp": now.Add(time.Hour).Unix(), "nbf": now.Add(time.Minute).Unix()}},
+		{
+			name:   "valid since past",
+			claims: map[string]any{"exp": now.Add(time.Hour).Unix(), "nbf": now.Add(-time.Minute).Unix()},
+			valid:  true,
+		},
+		{
+			name:    "unexpected issuer",
+			claims:  map[string]any{"exp": now.Add(time.Hour).Unix(), "iss": "https://evil.example.com"},
+			prepare: func(v *Verifier) { v.Issuer = "https://sso.example.com" },
+		},
+		{
+			name:    "missing issuer",
+			claims:  map[string]any{"exp": now.Add(time.Hour).Unix()},
+			prepare: func(v *Verifier) { v.Issuer = "https://sso.example.com" },
+		},
+		{
+			name:    "expected issuer",
+			claims:  map[string]any{"exp": now.Add(time.Hour).Unix(), "iss": "https://sso.example.com"},
+			prepare: func(v *Verifier) { v.Issuer = "https://sso.example.com" },
+			valid:   true,
+		},
+		{
+			name:    "unexpected audience",
+			claims:  map[string]any{"exp": now.Add(time.Hour).Unix(), "aud": "other"},
+			prepare: func(v *Verifier) { v.Audience = "review-assigner" },
+		},
+		{
+			name:    "audience string",
+			claims:  map[string]any{"exp": now.Add(time.Hour).Unix(), "aud": "review-assigner"},
+			prepare: func(v *Verifier) { v.Audience = "review-assigner" },
+			valid:   true,
+		},
+		{
+			name:    "audience array",
+			claims:  map[string]any{"exp": now.Add(time.Hour).Unix(), "aud": []string{"other", "review-assigner"}},
+			prepare: func(v *Verifier) { v.Audience = "review-assigner" },
+			valid:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewVerifier(keySet(t, key))
+			if tt.prepare != nil {
+				tt.prepare(verifier)
+			}
+			token := sign(t, key, "ES256", tt.claims)
+
+			if !tt.valid {
+				requireInvalid(t, verifier, token)
+				return
+			}
+			if _, err := verifier.Verify(context.Background(), token); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+		})
+	}
+}
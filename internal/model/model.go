@@ -2,6 +2,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -12,19 +13,37 @@ const (
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 )
 
+// DefaultRequiredReviewers is number of reviewers assigned to pull requests
+// when team doesn't specify it.
+const DefaultRequiredReviewers = 2
+
 // TeamMember represents a user who is part of a team.
 // Corresponds to #/components/schemas/TeamMember.
 type TeamMember struct {
 	UserID   string `json:"user_id" validate:"required,max=255"`
 	Username string `json:"username" validate:"required,max=255"`
 	IsActive bool   `json:"is_active"`
+	// ChatHandle mentions user in chat notifications.
+	ChatHandle string `json:"chat_handle,omitempty" validate:"max=255"`
+	// Email receives digests of pending reviews.
+	Email string `json:"email,omitempty" validate:"omitempty,email,max=255"`
 }
 
 // Team represents a collection of users.
 // Corresponds to #/components/schemas/Team.
 type Team struct {
-	Name    string       `json:"name" validate:"required,max=255"`
-	Members []TeamMember `json:"members" validate:"required,dive"`
+	Name string `json:"name" validate:"required,max=255"`
+	// RequiredReviewers is number of reviewers assigned to pull requests of team members.
+	// Zero value means DefaultRequiredReviewers.
+	RequiredReviewers int          `json:"required_reviewers" validate:"omitempty,min=1"`
+	Members           []TeamMember `json:"members" validate:"required,dive"`
+	// Leads are user ids of members who manage the team.
+	Leads []string `json:"leads,omitempty" validate:"dive,max=255"`
+	// ReviewSLASeconds is time open pull requests of team members may wait for review
+	// before they are escalated. Zero disables escalation.
+	ReviewSLASeconds int `json:"review_sla_seconds,omitempty" validate:"min=0"`
+	// ReviewSLAAutoReassign makes escalation replace reviewers of stale pull requests.
+	ReviewSLAAutoReassign bool `json:"review_sla_auto_reassign,omitempty"`
 }
 
 // User represents an individual user with their team and activity status.
@@ -34,6 +53,9 @@ type User struct {
 	Username string `json:"username" validate:"required,max=255"`
 	TeamName string `json:"team_name" validate:"required,max=255"`
 	IsActive bool   `json:"is_active"`
+	// ChatHandle and Email are described in TeamMember.
+	ChatHandle string `json:"chat_handle,omitempty" validate:"max=255"`
+	Email      string `json:"email,omitempty" validate:"omitempty,email,max=255"`
 }
 
 // PullRequestShort provides a basic, short representation of a pull request.
@@ -53,8 +75,207 @@ type PullRequest struct {
 	Name     string            `json:"name" validate:"required,max=255"`
 	AuthorID string            `json:"author_id" validate:"required,max=255"`
 	Status   PullRequestStatus `json:"status" validate:"required,oneof=OPEN MERGED"`
-	// Max 2 reviewers are assigned, as per API description/logic
-	AssignedReviewers []string   `json:"assigned_reviewers" validate:"max=2,dive,max=255"`
+	// Number of reviewers is defined by Team.RequiredReviewers of author's team
+	AssignedReviewers []string   `json:"assigned_reviewers" validate:"dive,max=255"`
 	CreatedAt         *time.Time `json:"createdAt,omitempty"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	// NeedMoreReviewers is set when author's team lacks active members
+	// to assign required number of reviewers.
+	NeedMoreReviewers bool `json:"needMoreReviewers"`
+}
+
+// Reassignment describes replacement of reviewer on a pull request.
+// NewReviewerID is empty if no replacement was found.
+// FallbackTeam is set if new reviewer was picked from fallback team instead of author's team.
+type Reassignment struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	FallbackTeam  string `json:"fallback_team,omitempty"`
+}
+
+// ReassignmentReport describes outcome of moving open reviews off deactivated users.
+type ReassignmentReport struct {
+	Reassigned []Reassignment `json:"reassigned"`
+	// NoCandidate lists pull requests reviewer was removed from without replacement.
+	NoCandidate []Reassignment `json:"no_candidate"`
+}
+
+// NewReassignmentReport returns empty report which is serialized with empty lists instead of nulls.
+func NewReassignmentReport() *ReassignmentReport {
+	return &ReassignmentReport{
+		Reassigned:  []Reassignment{},
+		NoCandidate: []Reassignment{},
+	}
+}
+
+// Escalation describes pull request which exceeded review SLA of author's team.
+// Reassigned and NoCandidate are filled if the team replaces reviewers of such pull requests.
+type Escalation struct {
+	PullRequestID string         `json:"pull_request_id"`
+	EscalatedAt   time.Time      `json:"escalated_at"`
+	Reassigned    []Reassignment `json:"reassigned"`
+	// NoCandidate lists reviewers who were kept because there was no one to replace them with.
+	NoCandidate []Reassignment `json:"no_candidate"`
+}
+
+type AssignmentEventType string
+
+const (
+	AssignmentEventASSIGNED   AssignmentEventType = "ASSIGNED"
+	AssignmentEventREASSIGNED AssignmentEventType = "REASSIGNED"
+	AssignmentEventUNASSIGNED AssignmentEventType = "UNASSIGNED"
+	AssignmentEventMERGED     AssignmentEventType = "MERGED"
+	// AssignmentEventESCALATED means pull request exceeded review SLA of author's team.
+	AssignmentEventESCALATED AssignmentEventType = "ESCALATED"
+)
+
+// Reasons of assignment events.
+const (
+	ReasonPullRequestCreated = "PR_CREATED"
+	ReasonPullRequestMerged  = "PR_MERGED"
+	ReasonManualReassign     = "MANUAL_REASSIGN"
+	ReasonUserDeactivated    = "USER_DEACTIVATED"
+	ReasonTeamDeactivated    = "TEAM_DEACTIVATED"
+	ReasonBackfill           = "BACKFILL"
+	ReasonReviewSLAExceeded  = "REVIEW_SLA_EXCEEDED"
+)
+
+// AssignmentEvent is a record in pull request review history.
+// OldReviewerID and NewReviewerID are set depending on event type.
+type AssignmentEvent struct {
+	ID            int64               `json:"id"`
+	PullRequestID string              `json:"pull_request_id"`
+	Type          AssignmentEventType `json:"type"`
+	Actor         string              `json:"actor"`
+	OldReviewerID string              `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string              `json:"new_reviewer_id,omitempty"`
+	Reason        string              `json:"reason"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+// UserStats describes review load of a user.
+// ReassignedFrom counts reviews that were reassigned from user to someone else.
+type UserStats struct {
+	UserID            string `json:"user_id"`
+	Username          string `json:"username"`
+	TeamName          string `json:"team_name"`
+	TotalAssignments  int    `json:"total_assignments"`
+	OpenAssignments   int    `json:"open_assignments"`
+	MergedAssignments int    `json:"merged_assignments"`
+	ReassignedFrom    int    `json:"reassigned_from"`
+}
+
+// TeamStats describes review load of current team members.
+// Reassignments counts replacements of reviewers who are members of the team now,
+// history doesn't keep team of reviewer at the time of replacement.
+// AverageTimeToMergeSeconds is computed over merged pull requests authored by team members
+// and is nil if there are none.
+type TeamStats struct {
+	TeamName                  string   `json:"team_name"`
+	TotalAssignments          int      `json:"total_assignments"`
+	OpenAssignments           int      `json:"open_assignments"`
+	MergedAssignments         int      `json:"merged_assignments"`
+	Reassignments             int      `json:"reassignments"`
+	AverageTimeToMergeSeconds *float64 `json:"average_time_to_merge_seconds"`
+}
+
+// Stats describes distribution of reviews.
+type Stats struct {
+	Users                     []UserStats    `json:"users"`
+	Teams                     []TeamStats    `json:"teams"`
+	Reassignments             int            `json:"reassignments"`
+	ReassignmentsByReason     map[string]int `json:"reassignments_by_reason"`
+	AverageTimeToMergeSeconds *float64       `json:"average_time_to_merge_seconds"`
+}
+
+// UserToken is api token of a user. Token itself is shown only once on issuance
+// and only its hash is stored, see IssuedUserToken.
+type UserToken struct {
+	ID        int64      `json:"id"`
+	UserID    string     `json:"user_id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IssuedUserToken is a newly issued UserToken together with token itself.
+type IssuedUserToken struct {
+	UserToken
+	Token string `json:"token"`
+}
+
+// Providers of external identities and webhooks.
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// ExternalIdentity maps login of user in external system, e.g. GitHub, to user id.
+// Logins are case-insensitive and stored in lower case.
+type ExternalIdentity struct {
+	Provider string `json:"provider"`
+	Login    string `json:"login"`
+	UserID   string `json:"user_id"`
+}
+
+type NotificationType string
+
+const (
+	NotificationReviewAssigned    NotificationType = "review.assigned"
+	NotificationReviewReassigned  NotificationType = "review.reassigned"
+	NotificationPullRequestMerged NotificationType = "pr.merged"
+	NotificationReviewEscalated   NotificationType = "review.escalated"
+)
+
+// Notification tells external systems about change of review assignments.
+// OldReviewerID and NewReviewerID are set depending on notification type.
+type Notification struct {
+	// ID is unique across notifications, receivers use it to drop duplicate deliveries.
+	ID            string           `json:"id"`
+	Type          NotificationType `json:"type"`
+	PullRequest   PullRequestShort `json:"pull_request"`
+	OldReviewerID string           `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string           `json:"new_reviewer_id,omitempty"`
+	Reason        string           `json:"reason"`
+	Actor         string           `json:"actor"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+type OutboxMessageStatus string
+
+const (
+	OutboxMessagePENDING OutboxMessageStatus = "PENDING"
+	OutboxMessageDONE    OutboxMessageStatus = "DONE"
+	// OutboxMessageFAILED means all attempts to process message failed.
+	OutboxMessageFAILED OutboxMessageStatus = "FAILED"
+)
+
+// OutboxMessage is domain event waiting in outbox for processing by Sink.
+// Type tells how to decode Payload, e.g. notification types.
+type OutboxMessage struct {
+	ID            int64
+	Sink          string
+	Type          string
+	Payload       json.RawMessage
+	Status        OutboxMessageStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	ProcessedAt   *time.Time
+}
+
+// Digest lists pending reviews of user, oldest first.
+type Digest struct {
+	User      User
+	Reviews   []PendingReview
+	CreatedAt time.Time
+}
+
+// PendingReview is open pull request user reviews. Age is time since its creation.
+type PendingReview struct {
+	PullRequest PullRequestShort
+	CreatedAt   time.Time
+	Age         time.Duration
 }
@@ -0,0 +1,164 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/outbox"
+	"review-assigner/internal/storage"
+)
+
+// ChatSink posts messages about assigned reviewers to chat incoming webhook.
+// Slack and Mattermost accept the same message format, they differ in mentions only.
+type ChatSink struct {
+	url    string
+	format string
+	users  storage.User
+	client *http.Client
+}
+
+var _ outbox.Sink = (*ChatSink)(nil)
+
+// NewChatSink creates sink of incoming webhook url. Format is config.ChatFormatSlack or config.ChatFormatMattermost.
+// Users are used to mention reviewers by their chat handles.
+func NewChatSink(url, format string, users storage.User, timeout time.Duration) *ChatSink {
+	return &ChatSink{
+		url:    url,
+		format: format,
+		users:  users,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name is "chat". It doesn't contain url, which is a secret, and stays the same when url is changed.
+func (s *ChatSink) Name() string {
+	return "chat"
+}
+
+// Handle posts message about assigned or reassigned reviewer, other notifications are ignored.
+func (s *ChatSink) Handle(ctx context.Context, message model.OutboxMessage) error {
+	notification, ok, err := decodeNotification(message)
+	if !ok || err != nil {
+		return err
+	}
+
+	var text string
+	switch notification.Type {
+	case model.NotificationReviewAssigned, model.NotificationReviewReassigned:
+		text, err = s.reviewText(ctx, notification)
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	body, err := json.Marshal(chatMessage{Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post chat message: %w", err)
+	}
+	defer resp.Body.Close()
+	// drain body so connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("chat responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// chatMessage is incoming webhook payload.
+type chatMessage struct {
+	Text string `json:"text"`
+}
+
+// reviewText describes assignment, e.g. "@bob please review "Add login" (pr-1) by Alice, replacing Carol".
+func (s *ChatSink) reviewText(ctx context.Context, notification model.Notification) (string, error) {
+	reviewer, err := s.user(ctx, notification.NewReviewerID)
+	if err != nil {
+		return "", err
+	}
+	author, err := s.user(ctx, notification.PullRequest.AuthorID)
+	if err != nil {
+		return "", err
+	}
+
+	text := fmt.Sprintf("%s please review %s (%s) by %s",
+		s.mention(reviewer), s.escape(`"`+notification.PullRequest.Name+`"`),
+		s.escape(notification.PullRequest.Id), s.escape(author.Username))
+
+	if notification.OldReviewerID != "" {
+		oldReviewer, err := s.user(ctx, notification.OldReviewerID)
+		if err != nil {
+			return "", err
+		}
+		text += ", replacing " + s.escape(oldReviewer.Username)
+	}
+
+	return text, nil
+}
+
+// user returns user by id. Unknown user is described by id only.
+func (s *ChatSink) user(ctx context.Context, id string) (*model.User, error) {
+	user, err := s.users.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			return &model.User{Id: id, Username: id}, nil
+		}
+		return nil, fmt.Errorf("storage failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// mention returns mention of user by chat handle, or just username if user has no handle.
+func (s *ChatSink) mention(user *model.User) string {
+	if user.ChatHandle == "" {
+		return s.escape(user.Username)
+	}
+
+	handle := strings.TrimPrefix(user.ChatHandle, "@")
+	if s.format == config.ChatFormatSlack {
+		// Slack mentions by member id, e.g. <@U024BE7LH>
+		return "<@" + handle + ">"
+	}
+	return "@" + handle
+}
+
+// escape protects user supplied text, e.g. pull request names coming from webhooks,
+// from being parsed as Slack control sequences or Mattermost markdown and mentions.
+func (s *ChatSink) escape(text string) string {
+	if s.format == config.ChatFormatSlack {
+		return slackEscaper.Replace(text)
+	}
+	return mattermostEscaper.Replace(text)
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// mattermostEscaper escapes markdown with backslashes and breaks mentions, e.g. @channel,
+// with zero-width joiner after @, so they are shown as is but don't notify anyone.
+var mattermostEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "~", `\~`, "#", `\#`, "|", `\|`,
+	"[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "@", "@\u200d",
+)
@@ -0,0 +1,219 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/model"
+	"review-assigner/internal/notify"
+	"review-assigner/internal/storage/memory"
+)
+
+// chatStub is incoming webhook which records texts of posted messages.
+type chatStub struct {
+	*httptest.Server
+	texts  chan string
+	status int
+}
+
+func newChatStub(t *testing.T, status int) *chatStub {
+	t.Helper()
+
+	stub := &chatStub{texts: make(chan string, 1), status: status}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var message struct {
+			Text string `json:"text"`
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" ||
+			json.Unmarshal(body, &message) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		stub.texts <- message.Text
+		w.WriteHeader(stub.status)
+	}))
+	t.Cleanup(stub.Close)
+
+	return stub
+}
+
+// newUsers stores reviewers bob and carol and author alice, names may need escaping.
+func newUsers(t *testing.T, bobHandle string) *memory.Storage {
+	t.Helper()
+
+	ctx := context.Background()
+	st := memory.New()
+	if _, err := st.AddTeam(ctx, "backend", 0); err != nil {
+		t.Fatalf("failed to add team: %v", err)
+	}
+	_, err := st.AddUpdateUsers(ctx, []model.User{
+		{Id: "u1", Username: "Alice_<admin>", TeamName: "backend", IsActive: true},
+		{Id: "u2", Username: "@bob", TeamName: "backend", IsActive: true, ChatHandle: bobHandle},
+		{Id: "u3", Username: "Carol & co", TeamName: "backend", IsActive: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to add users: %v", err)
+	}
+	return st
+}
+
+func outboxMessage(t *testing.T, notification model.Notification) model.OutboxMessage {
+	t.Helper()
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		t.Fatalf("failed to marshal notification: %v", err)
+	}
+	return model.OutboxMessage{ID: 1, Type: string(notification.Type), Payload: payload}
+}
+
+func reassigned(name string) model.Notification {
+	return model.Notification{
+		ID:   "n1",
+		Type: model.NotificationReviewReassigned,
+		PullRequest: model.PullRequestShort{
+			Id:       "pr-1",
+			Name:     name,
+			AuthorID: "u1",
+			Status:   model.PullRequestStatusOPEN,
+		},
+		OldReviewerID: "u3",
+		NewReviewerID: "u2",
+		CreatedAt:     time.Now(),
+	}
+}
+
+func TestChatSinkHandle(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		bobHandle string
+		prName    string
+		want      string
+	}{
+		{
+			name:      "slack mention",
+			format:    config.ChatFormatSlack,
+			bobHandle: "U024BE7LH",
+			prName:    "Add login",
+			want:      `<@U024BE7LH> please review "Add login" (pr-1) by Alice_&lt;admin&gt;, replacing Carol &amp; co`,
+		},
+		{
+			name:      "slack escaping",
+			format:    config.ChatFormatSlack,
+			bobHandle: "@U024BE7LH",
+			prName:    "<!channel> fix & <http://evil|retry>",
+			want: `<@U024BE7LH> please review "&lt;!channel&gt; fix &amp; &lt;http://evil|retry&gt;" (pr-1)` +
+				` by Alice_&lt;admin&gt;, replacing Carol &amp; co`,
+		},
+		{
+			name:   "slack without handle",
+			format: config.ChatFormatSlack,
+			prName: "Add login",
+			want:   `@bob please review "Add login" (pr-1) by Alice_&lt;admin&gt;, replacing Carol &amp; co`,
+		},
+		{
+			name:      "mattermost mention",
+			format:    config.ChatFormatMattermost,
+			bobHandle: "@bob.smith",
+			prName:    "Add login",
+			want:      `@bob.smith please review "Add login" (pr-1) by Alice\_\<admin\>, replacing Carol & co`,
+		},
+		{
+			name:      "mattermost escaping",
+			format:    config.ChatFormatMattermost,
+			bobHandle: "bob.smith",
+			prName:    "@channel **fix** [retry](http://evil) `#1`",
+			want: "@bob.smith please review \"@\u200dchannel \\*\\*fix\\*\\* \\[retry\\](http://evil) \\`\\#1\\`\" (pr-1)" +
+				` by Alice\_\<admin\>, replacing Carol & co`,
+		},
+		{
+			name:   "mattermost without handle",
+			format: config.ChatFormatMattermost,
+			prName: "Add login",
+			want:   "@\u200dbob please review \"Add login\" (pr-1) by Alice\\_\\<admin\\>, replacing Carol & co",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newChatStub(t, http.StatusOK)
+			sink := notify.NewChatSink(stub.URL, tt.format, newUsers(t, tt.bobHandle), time.Second)
+
+			err := sink.Handle(context.Background(), outboxMessage(t, reassigned(tt.prName)))
+			if err != nil {
+				t.Fatalf("failed to handle message: %v", err)
+			}
+
+			select {
+			case got := <-stub.texts:
+				if got != tt.want {
+					t.Fatalf("unexpected text\nwant: %q\ngot:  %q", tt.want, got)
+				}
+			default:
+				t.Fatal("message was not posted")
+			}
+		})
+	}
+}
+
+func TestChatSinkHandleAssigned(t *testing.T) {
+	stub := newChatStub(t, http.StatusOK)
+	sink := notify.NewChatSink(stub.URL, config.ChatFormatMattermost, newUsers(t, "bob"), time.Second)
+
+	notification := reassigned("Add login")
+	notification.Type = model.NotificationReviewAssigned
+	notification.OldReviewerID = ""
+	notification.NewReviewerID = "unknown"
+
+	if err := sink.Handle(context.Background(), outboxMessage(t, notification)); err != nil {
+		t.Fatalf("failed to handle message: %v", err)
+	}
+
+	want := `unknown please review "Add login" (pr-1) by Alice\_\<admin\>`
+	if got := <-stub.texts; got != want {
+		t.Fatalf("unexpected text\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestChatSinkHandleIgnored(t *testing.T) {
+	stub := newChatStub(t, http.StatusOK)
+	sink := notify.NewChatSink(stub.URL, config.ChatFormatSlack, newUsers(t, ""), time.Second)
+
+	merged := reassigned("Add login")
+	merged.Type = model.NotificationPullRequestMerged
+
+	messages := []model.OutboxMessage{
+		outboxMessage(t, merged),
+		{ID: 2, Type: "digest", Payload: json.RawMessage(`{}`)},
+	}
+	for _, message := range messages {
+		if err := sink.Handle(context.Background(), message); err != nil {
+			t.Fatalf("failed to handle %s message: %v", message.Type, err)
+		}
+	}
+
+	select {
+	case got := <-stub.texts:
+		t.Fatalf("unexpected message posted: %q", got)
+	default:
+	}
+}
+
+func TestChatSinkHandleErrorStatus(t *testing.T) {
+	stub := newChatStub(t, http.StatusInternalServerError)
+	sink := notify.NewChatSink(stub.URL, config.ChatFormatSlack, newUsers(t, ""), time.Second)
+
+	if err := sink.Handle(context.Background(), outboxMessage(t, reassigned("Add login"))); err == nil {
+		t.Fatal("want error for non 2xx response, got nil")
+	}
+}
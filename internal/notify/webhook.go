@@ -0,0 +1,130 @@
+// Package notify delivers notifications about review assignments from outbox to external endpoints.
+// Notifications are dispatched by outbox.Dispatcher to sinks of this package.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"review-assigner/internal/model"
+	"review-assigner/internal/outbox"
+)
+
+// Headers of notification requests.
+const (
+	EventHeader    = "X-Review-Assigner-Event"
+	DeliveryHeader = "X-Review-Assigner-Delivery"
+	// SignatureHeader is "sha256=" followed by hex HMAC-SHA256 of body.
+	SignatureHeader = "X-Review-Assigner-Signature-256"
+)
+
+// WebhookSender posts notifications as JSON signed with shared secret.
+type WebhookSender struct {
+	client *http.Client
+	secret []byte
+}
+
+func NewWebhookSender(secret string, timeout time.Duration) *WebhookSender {
+	return &WebhookSender{
+		client: &http.Client{Timeout: timeout},
+		secret: []byte(secret),
+	}
+}
+
+// Send posts notification to endpoint. Response with status other than 2xx is an error.
+func (s *WebhookSender) Send(ctx context.Context, endpoint string, notification model.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(notification.Type))
+	req.Header.Set(DeliveryHeader, notification.ID)
+	req.Header.Set(SignatureHeader, Sign(s.secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+	// drain body so connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// WebhookSink sends notifications from outbox to single endpoint.
+type WebhookSink struct {
+	name     string
+	endpoint string
+	sender   *WebhookSender
+}
+
+var _ outbox.Sink = (*WebhookSink)(nil)
+
+// NewWebhookSink creates sink of endpoint named name, see WebhookSinkName. Sender may be shared between sinks.
+func NewWebhookSink(name, endpoint string, sender *WebhookSender) *WebhookSink {
+	return &WebhookSink{name: name, endpoint: endpoint, sender: sender}
+}
+
+// WebhookSinkName returns name of sink of i-th endpoint: "webhook" for the first one and "webhook:<i+1>" for others.
+// Name doesn't depend on endpoint, so pending messages are sent to new endpoint when it is changed.
+func WebhookSinkName(i int) string {
+	if i == 0 {
+		return "webhook"
+	}
+	return fmt.Sprintf("webhook:%d", i+1)
+}
+
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+func (s *WebhookSink) Handle(ctx context.Context, message model.OutboxMessage) error {
+	notification, ok, err := decodeNotification(message)
+	if !ok || err != nil {
+		return err
+	}
+	return s.sender.Send(ctx, s.endpoint, notification)
+}
+
+// decodeNotification returns notification from message. It returns false if message
+// is not a notification.
+func decodeNotification(message model.OutboxMessage) (model.Notification, bool, error) {
+	switch model.NotificationType(message.Type) {
+	case model.NotificationReviewAssigned, model.NotificationReviewReassigned, model.NotificationPullRequestMerged,
+		model.NotificationReviewEscalated:
+	default:
+		return model.Notification{}, false, nil
+	}
+
+	var notification model.Notification
+	if err := json.Unmarshal(message.Payload, &notification); err != nil {
+		return model.Notification{}, false, fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+	return notification, true, nil
+}
+
+// Sign returns value of SignatureHeader for body. Receivers compute it with the same secret
+// and compare in constant time.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,173 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/model"
+	"review-assigner/internal/notify"
+	"review-assigner/internal/outbox"
+	"review-assigner/internal/storage/memory"
+)
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 test vector from RFC 4231, test case 2
+	got := notify.Sign([]byte("Jefe"), []byte("what do ya want for nothing?"))
+	want := "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+}
+
+// webhookRequest is request received by webhookStub.
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookStub is notification receiver which responds with given statuses in turn, then with 200.
+type webhookStub struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []webhookRequest
+}
+
+func newWebhookStub(t *testing.T, statuses ...int) *webhookStub {
+	t.Helper()
+
+	stub := &webhookStub{statuses: statuses}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		stub.mu.Lock()
+		defer stub.mu.Unlock()
+
+		stub.requests = append(stub.requests, webhookRequest{header: r.Header, body: body})
+		status := http.StatusOK
+		if len(stub.statuses) > 0 {
+			status, stub.statuses = stub.statuses[0], stub.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(stub.Close)
+
+	return stub
+}
+
+func (s *webhookStub) Requests() []webhookRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]webhookRequest(nil), s.requests...)
+}
+
+// requireSigned checks that request carries notification signed with secret.
+func requireSigned(t *testing.T, request webhookRequest, secret string, want model.Notification) {
+	t.Helper()
+
+	if got := request.header.Get(notify.SignatureHeader); got != notify.Sign([]byte(secret), request.body) {
+		t.Fatalf("invalid signature %q", got)
+	}
+	if got := request.header.Get(notify.EventHeader); got != string(want.Type) {
+		t.Fatalf("want event %s, got %q", want.Type, got)
+	}
+	if got := request.header.Get(notify.DeliveryHeader); got != want.ID {
+		t.Fatalf("want delivery %s, got %q", want.ID, got)
+	}
+
+	var notification model.Notification
+	if err := json.Unmarshal(request.body, &notification); err != nil {
+		t.Fatalf("invalid notification body: %v", err)
+	}
+	if notification.ID != want.ID || notification.PullRequest.Id != want.PullRequest.Id {
+		t.Fatalf("unexpected notification %+v", notification)
+	}
+}
+
+func TestWebhookSinkHandle(t *testing.T) {
+	stub := newWebhookStub(t, http.StatusInternalServerError, http.StatusMultipleChoices)
+	sink := notify.NewWebhookSink("webhook", stub.URL, notify.NewWebhookSender("secret", time.Second))
+	notification := reassigned("Add login")
+	message := outboxMessage(t, notification)
+
+	// only 2xx is delivery
+	for range 2 {
+		if err := sink.Handle(context.Background(), message); err == nil {
+			t.Fatal("want error for non-2xx response")
+		}
+	}
+	if err := sink.Handle(context.Background(), message); err != nil {
+		t.Fatalf("failed to handle message: %v", err)
+	}
+
+	requests := stub.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("want 3 requests, got %d", len(requests))
+	}
+	for _, request := range requests {
+		requireSigned(t, request, "secret", notification)
+	}
+
+	// messages which are not notifications are ignored
+	if err := sink.Handle(context.Background(), model.OutboxMessage{ID: 2, Type: "digest", Payload: json.RawMessage(`{}`)}); err != nil {
+		t.Fatalf("failed to ignore message: %v", err)
+	}
+	if len(stub.Requests()) != 3 {
+		t.Fatal("ignored message was sent")
+	}
+}
+
+func TestWebhookSinkName(t *testing.T) {
+	for i, want := range []string{"webhook", "webhook:2", "webhook:3"} {
+		if got := notify.WebhookSinkName(i); got != want {
+			t.Fatalf("want name %s of endpoint %d, got %s", want, i, got)
+		}
+	}
+}
+
+func TestWebhookSinkRetry(t *testing.T) {
+	stub := newWebhookStub(t, http.StatusServiceUnavailable, http.StatusBadGateway)
+	sink := notify.NewWebhookSink("webhook", stub.URL, notify.NewWebhookSender("secret", time.Second))
+	st := memory.New()
+	dispatcher := outbox.NewDispatcher(st, config.OutboxConfig{
+		Lease:           time.Minute,
+		MaxAttempts:     5,
+		RetryBackoff:    time.Millisecond,
+		RetryMaxBackoff: time.Millisecond,
+	}, sink)
+
+	notification := reassigned("Add login")
+	message := outboxMessage(t, notification)
+	message.Sink = sink.Name()
+	message.Status = model.OutboxMessagePENDING
+	message.NextAttemptAt = time.Now()
+	message.CreatedAt = time.Now()
+	if err := st.AddOutboxMessages(context.Background(), []model.OutboxMessage{message}); err != nil {
+		t.Fatalf("AddOutboxMessages: %v", err)
+	}
+
+	// notification is redelivered until endpoint accepts it
+	for range 5 {
+		time.Sleep(5 * time.Millisecond)
+		if err := dispatcher.DispatchDue(context.Background()); err != nil {
+			t.Fatalf("DispatchDue: %v", err)
+		}
+	}
+
+	requests := stub.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("want 2 failed deliveries and successful one, got %d requests", len(requests))
+	}
+	for _, request := range requests {
+		requireSigned(t, request, "secret", notification)
+	}
+}
@@ -0,0 +1,148 @@
+// Package outbox dispatches domain events from transactional outbox to sinks, e.g. notification webhooks.
+//
+// Service adds events to outbox in the same transaction as the change they describe,
+// one message per sink, so events are neither lost on crash nor sent for rolled back changes.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage"
+)
+
+// batchSize is number of messages claimed at once. Lease must be long enough to process the whole batch.
+const batchSize = 10
+
+// Sink processes outbox messages, e.g. sends notifications.
+type Sink interface {
+	// Name is stored with messages of sink, so it must be stable across restarts.
+	Name() string
+
+	// Handle processes message. Message types sink doesn't handle are ignored.
+	// Returned error makes dispatcher retry message later.
+	Handle(ctx context.Context, message model.OutboxMessage) error
+}
+
+// Dispatcher claims due messages and hands them to their sinks. Failed messages are retried
+// with exponential backoff. Processing is at least once, sinks must tolerate duplicates.
+type Dispatcher struct {
+	outbox storage.Outbox
+	sinks  map[string]Sink
+	cfg    config.OutboxConfig
+}
+
+func NewDispatcher(outbox storage.Outbox, cfg config.OutboxConfig, sinks ...Sink) *Dispatcher {
+	bySink := make(map[string]Sink, len(sinks))
+	for _, sink := range sinks {
+		bySink[sink.Name()] = sink
+	}
+	return &Dispatcher{outbox: outbox, sinks: bySink, cfg: cfg}
+}
+
+// SinkNames returns names of sinks, see service.NewService.
+func SinkNames(sinks []Sink) []string {
+	names := make([]string, len(sinks))
+	for i, sink := range sinks {
+		names[i] = sink.Name()
+	}
+	return names
+}
+
+// Run dispatches messages every poll interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("failed to dispatch outbox messages", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue processes all messages which are due by now.
+func (d *Dispatcher) DispatchDue(ctx context.Context) error {
+	for {
+		messages, err := d.outbox.ClaimOutboxMessages(ctx, time.Now(), d.cfg.Lease, batchSize)
+		if err != nil {
+			return fmt.Errorf("storage failed to claim outbox messages: %w", err)
+		}
+
+		for _, message := range messages {
+			if err := d.dispatch(ctx, message); err != nil {
+				return err
+			}
+		}
+
+		if len(messages) < batchSize {
+			return nil
+		}
+	}
+}
+
+// dispatch makes single attempt to process message and saves its outcome.
+func (d *Dispatcher) dispatch(ctx context.Context, message model.OutboxMessage) error {
+	sink, ok := d.sinks[message.Sink]
+	if !ok {
+		// sink was removed from config, message is kept for inspection
+		message.Status = model.OutboxMessageFAILED
+		message.LastError = "unknown sink"
+		slog.Error("outbox message has unknown sink", "message_id", message.ID, "sink", message.Sink)
+		return d.update(ctx, &message)
+	}
+
+	handleErr := sink.Handle(ctx, message)
+	if handleErr != nil && ctx.Err() != nil {
+		// attempt is interrupted by shutdown, message is claimed again after lease
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	message.Attempts++
+	switch {
+	case handleErr == nil:
+		message.Status = model.OutboxMessageDONE
+		message.ProcessedAt = &now
+		message.LastError = ""
+	case message.Attempts >= d.cfg.MaxAttempts:
+		message.Status = model.OutboxMessageFAILED
+		message.LastError = handleErr.Error()
+		slog.Error("outbox message failed", "message_id", message.ID, "sink", message.Sink,
+			"type", message.Type, "attempts", message.Attempts, "error", handleErr)
+	default:
+		message.NextAttemptAt = now.Add(d.backoff(message.Attempts))
+		message.LastError = handleErr.Error()
+		slog.Warn("outbox message attempt failed", "message_id", message.ID, "sink", message.Sink,
+			"attempts", message.Attempts, "next_attempt_at", message.NextAttemptAt, "error", handleErr)
+	}
+
+	return d.update(ctx, &message)
+}
+
+func (d *Dispatcher) update(ctx context.Context, message *model.OutboxMessage) error {
+	if err := d.outbox.UpdateOutboxMessage(ctx, message); err != nil {
+		return fmt.Errorf("storage failed to update outbox message: %w", err)
+	}
+	return nil
+}
+
+// backoff returns delay after given number of failed attempts: RetryBackoff doubled
+// with every attempt after the first one, but not more than RetryMaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.RetryBackoff
+	for i := 1; i < attempts && delay < d.cfg.RetryMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.RetryMaxBackoff)
+}
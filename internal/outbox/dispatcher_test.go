@@ -0,0 +1,214 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/model"
+	"review-assigner/internal/outbox"
+)
+
+// fakeOutbox claims every pending message regardless of its next attempt time,
+// so retries are dispatched without waiting for backoff.
+type fakeOutbox struct {
+	messages []model.OutboxMessage
+	claims   int
+}
+
+func (o *fakeOutbox) AddOutboxMessages(_ context.Context, messages []model.OutboxMessage) error {
+	o.messages = append(o.messages, messages...)
+	return nil
+}
+
+func (o *fakeOutbox) ClaimOutboxMessages(_ context.Context, _ time.Time, _ time.Duration, limit int) ([]model.OutboxMessage, error) {
+	o.claims++
+
+	var claimed []model.OutboxMessage
+	for _, message := range o.messages {
+		if message.Status == model.OutboxMessagePENDING && len(claimed) < limit {
+			claimed = append(claimed, message)
+		}
+	}
+	return claimed, nil
+}
+
+func (o *fakeOutbox) UpdateOutboxMessage(_ context.Context, message *model.OutboxMessage) error {
+	for i := range o.messages {
+		if o.messages[i].ID == message.ID {
+			o.messages[i] = *message
+			return nil
+		}
+	}
+	return fmt.Errorf("message %d not found", message.ID)
+}
+
+// fakeSink fails first failures calls.
+type fakeSink struct {
+	failures int
+	handled  []int64
+}
+
+func (s *fakeSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeSink) Handle(_ context.Context, message model.OutboxMessage) error {
+	s.handled = append(s.handled, message.ID)
+	if len(s.handled) <= s.failures {
+		return errors.New("endpoint is unavailable")
+	}
+	return nil
+}
+
+var testConfig = config.OutboxConfig{
+	Lease:           time.Minute,
+	MaxAttempts:     4,
+	RetryBackoff:    time.Second,
+	RetryMaxBackoff: 3 * time.Second,
+}
+
+func newOutbox(t *testing.T, sink string, n int) *fakeOutbox {
+	t.Helper()
+
+	o := &fakeOutbox{}
+	for i := range n {
+		o.messages = append(o.messages, model.OutboxMessage{
+			ID:            int64(i + 1),
+			Sink:          sink,
+			Type:          string(model.NotificationReviewAssigned),
+			Status:        model.OutboxMessagePENDING,
+			NextAttemptAt: time.Now(),
+		})
+	}
+	return o
+}
+
+func dispatchDue(t *testing.T, dispatcher *outbox.Dispatcher) {
+	t.Helper()
+
+	if err := dispatcher.DispatchDue(context.Background()); err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+}
+
+func TestDispatchDue(t *testing.T) {
+	sink := &fakeSink{}
+	o := newOutbox(t, sink.Name(), 25)
+	dispatchDue(t, outbox.NewDispatcher(o, testConfig, sink))
+
+	// messages are claimed in batches until fewer than a batch is left
+	if o.claims != 3 || len(sink.handled) != 25 {
+		t.Fatalf("want 25 messages handled in 3 claims, got %d in %d", len(sink.handled), o.claims)
+	}
+	for _, message := range o.messages {
+		if message.Status != model.OutboxMessageDONE || message.Attempts != 1 || message.ProcessedAt == nil {
+			t.Fatalf("want message processed, got %+v", message)
+		}
+	}
+}
+
+func TestDispatchDueRetry(t *testing.T) {
+	sink := &fakeSink{failures: 2}
+	o := newOutbox(t, sink.Name(), 1)
+	dispatcher := outbox.NewDispatcher(o, testConfig, sink)
+
+	for attempt, backoff := range []time.Duration{time.Second, 2 * time.Second} {
+		before := time.Now()
+		dispatchDue(t, dispatcher)
+
+		message := o.messages[0]
+		if message.Status != model.OutboxMessagePENDING || message.Attempts != attempt+1 || message.LastError == "" {
+			t.Fatalf("want message pending after failed attempt %d, got %+v", attempt+1, message)
+		}
+		if delay := message.NextAttemptAt.Sub(before); delay < backoff || delay > backoff+time.Second {
+			t.Fatalf("want retry in %s after attempt %d, got %s", backoff, attempt+1, delay)
+		}
+	}
+
+	dispatchDue(t, dispatcher)
+	message := o.messages[0]
+	if message.Status != model.OutboxMessageDONE || message.Attempts != 3 || message.LastError != "" {
+		t.Fatalf("want message processed on third attempt, got %+v", message)
+	}
+}
+
+func TestDispatchDueMaxAttempts(t *testing.T) {
+	sink := &fakeSink{failures: 10}
+	o := newOutbox(t, sink.Name(), 1)
+	dispatcher := outbox.NewDispatcher(o, testConfig, sink)
+
+	var backoffs []time.Duration
+	for range testConfig.MaxAttempts - 1 {
+		before := time.Now()
+		dispatchDue(t, dispatcher)
+		backoffs = append(backoffs, o.messages[0].NextAttemptAt.Sub(before).Round(time.Second))
+	}
+	// backoff doubles but doesn't exceed RetryMaxBackoff
+	if fmt.Sprint(backoffs) != "[1s 2s 3s]" {
+		t.Fatalf("want backoffs [1s 2s 3s], got %v", backoffs)
+	}
+
+	dispatchDue(t, dispatcher)
+	message := o.messages[0]
+	if message.Status != model.OutboxMessageFAILED || message.Attempts != testConfig.MaxAttempts {
+		t.Fatalf("want message failed after %d attempts, got %+v", testConfig.MaxAttempts, message)
+	}
+	if message.LastError != "endpoint is unavailable" {
+		t.Fatalf("want last error kept, got %q", message.LastError)
+	}
+
+	// failed message is not retried
+	dispatchDue(t, dispatcher)
+	if len(sink.handled) != testConfig.MaxAttempts {
+		t.Fatalf("want %d attempts, got %d", testConfig.MaxAttempts, len(sink.handled))
+	}
+}
+
+func TestDispatchDueUnknownSink(t *testing.T) {
+	sink := &fakeSink{}
+	o := newOutbox(t, "removed", 1)
+	dispatchDue(t, outbox.NewDispatcher(o, testConfig, sink))
+
+	message := o.messages[0]
+	if message.Status != model.OutboxMessageFAILED || message.LastError != "unknown sink" || message.Attempts != 0 {
+		t.Fatalf("want message failed with unknown sink, got %+v", message)
+	}
+	if len(sink.handled) != 0 {
+		t.Fatalf("want no messages handled, got %v", sink.handled)
+	}
+}
+
+// cancelingSink cancels context while handling message, like shutdown does.
+type cancelingSink struct {
+	cancel context.CancelFunc
+}
+
+func (s *cancelingSink) Name() string {
+	return "fake"
+}
+
+func (s *cancelingSink) Handle(ctx context.Context, _ model.OutboxMessage) error {
+	s.cancel()
+	return ctx.Err()
+}
+
+func TestDispatchDueCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := &cancelingSink{cancel: cancel}
+	o := newOutbox(t, sink.Name(), 1)
+
+	err := outbox.NewDispatcher(o, testConfig, sink).DispatchDue(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+
+	// interrupted attempt is not counted, message is claimed again after lease
+	if message := o.messages[0]; message.Status != model.OutboxMessagePENDING || message.Attempts != 0 {
+		t.Fatalf("want message untouched, got %+v", message)
+	}
+}
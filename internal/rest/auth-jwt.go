@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/jwt"
+	"review-assigner/internal/service"
+)
+
+// jwtAuthenticator authenticates callers with JWT in Authorization: Bearer header,
+// e.g. issued by company SSO. Identity is taken from claims.
+type jwtAuthenticator struct {
+	verifier *jwt.Verifier
+	cfg      config.JWTConfig
+}
+
+func newJWTAuthenticator(cfg config.JWTConfig) (*jwtAuthenticator, error) {
+	var keys jwt.KeySource
+	if cfg.JWKSFile != "" {
+		set, err := jwt.LoadKeySetFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = set
+	} else {
+		keys = jwt.NewRemoteKeySet(cfg.JWKSURL, cfg.JWKSRefreshInterval)
+	}
+
+	verifier := jwt.NewVerifier(keys)
+	verifier.Issuer = cfg.Issuer
+	verifier.Audience = cfg.Audience
+	verifier.Leeway = cfg.Leeway
+
+	return &jwtAuthenticator{verifier: verifier, cfg: cfg}, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(r *http.Request) (service.Identity, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return service.Identity{}, UnauthenticatedError{Reason: "missing bearer token"}
+	}
+
+	claims, err := a.verifier.Verify(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, jwt.InvalidTokenErr) {
+			return service.Identity{}, UnauthenticatedError{Reason: err.Error()}
+		}
+		return service.Identity{}, fmt.Errorf("failed to verify bearer token: %w", err)
+	}
+
+	userID := claims.String(a.cfg.UserClaim)
+	if userID == "" {
+		return service.Identity{}, UnauthenticatedError{Reason: fmt.Sprintf("token has no %s claim", a.cfg.UserClaim)}
+	}
+
+	return service.Identity{
+		UserID: userID,
+		Admin:  slices.Contains(claims.Strings(a.cfg.RolesClaim), a.cfg.AdminRole),
+	}, nil
+}
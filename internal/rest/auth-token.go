@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/service"
+)
+
+const (
+	adminTokenHeader = "X-Admin-Token"
+	userTokenHeader  = "X-User-Token"
+)
+
+// tokenAuthenticator authenticates admin with static X-Admin-Token
+// and active users with their api tokens in X-User-Token.
+type tokenAuthenticator struct {
+	adminToken string
+	service    *service.Service
+}
+
+func newTokenAuthenticator(adminToken string, s *service.Service) *tokenAuthenticator {
+	return &tokenAuthenticator{adminToken: adminToken, service: s}
+}
+
+func (a *tokenAuthenticator) Authenticate(r *http.Request) (service.Identity, error) {
+	if token := r.Header.Get(adminTokenHeader); token != "" {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.adminToken)) != 1 {
+			return service.Identity{}, UnauthenticatedError{Reason: "invalid admin token"}
+		}
+		return service.Identity{Admin: true}, nil
+	}
+
+	token := r.Header.Get(userTokenHeader)
+	if token == "" {
+		return service.Identity{}, UnauthenticatedError{Reason: "missing admin or user token"}
+	}
+	user, err := a.service.AuthenticateUserToken(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			return service.Identity{}, UnauthenticatedError{Reason: "invalid user token"}
+		}
+		return service.Identity{}, fmt.Errorf("service failed to authenticate user token: %w", err)
+	}
+	if !user.IsActive {
+		return service.Identity{}, UnauthenticatedError{Reason: "user is inactive"}
+	}
+
+	return service.Identity{UserID: user.Id}, nil
+}
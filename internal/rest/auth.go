@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/rest/payload"
+	"review-assigner/internal/service"
+)
+
+// Authenticator resolves identity of api caller from request.
+type Authenticator interface {
+	// Authenticate returns UnauthenticatedError if request carries no valid credentials.
+	Authenticate(r *http.Request) (service.Identity, error)
+}
+
+// UnauthenticatedError is returned by Authenticator for requests with missing or invalid credentials.
+type UnauthenticatedError struct {
+	Reason string
+}
+
+func (e UnauthenticatedError) Error() string {
+	return e.Reason
+}
+
+// NewAuthenticator creates Authenticator of mode chosen in cfg.
+func NewAuthenticator(cfg config.AuthConfig, s *service.Service) (Authenticator, error) {
+	switch cfg.Mode {
+	case config.AuthModeToken:
+		return newTokenAuthenticator(cfg.AdminToken, s), nil
+	case config.AuthModeJWT:
+		return newJWTAuthenticator(*cfg.JWT)
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", cfg.Mode)
+	}
+}
+
+// auth wraps handlers with authentication and puts identity of caller into request context.
+type auth struct {
+	authenticator Authenticator
+}
+
+// adminOnly allows requests of admins only.
+func (a *auth) adminOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := a.authenticate(w, r)
+		if !ok {
+			return
+		}
+		if !identity.Admin {
+			writeForbidden(w, "admin rights required")
+			return
+		}
+		next(w, r.WithContext(service.WithIdentity(r.Context(), identity)))
+	}
+}
+
+// adminOrUser allows requests of any authenticated caller.
+func (a *auth) adminOrUser(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := a.authenticate(w, r)
+		if !ok {
+			return
+		}
+		next(w, r.WithContext(service.WithIdentity(r.Context(), identity)))
+	}
+}
+
+// authenticate writes error response and returns false if request is not authenticated.
+func (a *auth) authenticate(w http.ResponseWriter, r *http.Request) (service.Identity, bool) {
+	identity, err := a.authenticator.Authenticate(r)
+	if err != nil {
+		var unauthenticatedErr UnauthenticatedError
+		if errors.As(err, &unauthenticatedErr) {
+			writeUnauthorized(w, unauthenticatedErr.Reason)
+			return service.Identity{}, false
+		}
+		slog.Error("failed to authenticate request", "error", err)
+		writeError(w, "internal server error", http.StatusInternalServerError)
+		return service.Identity{}, false
+	}
+	return identity, true
+}
+
+// writeUnauthorized writes 401 ErrorResponse.
+// openapi defines no code for authorization errors, so NOT_FOUND is used as for other errors.
+func writeUnauthorized(w http.ResponseWriter, msg string) {
+	writeError(w, msg, http.StatusUnauthorized)
+}
+
+// writeForbidden writes 403 ErrorResponse for authenticated caller without required rights.
+func writeForbidden(w http.ResponseWriter, msg string) {
+	writeError(w, msg, http.StatusForbidden)
+}
+
+func writeError(w http.ResponseWriter, msg string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	response := payload.ErrorResponse{
+		Error: payload.InnerError{
+			Code:    payload.ErrCodeNOT_FOUND,
+			Message: msg,
+		},
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write JSON response", "error", err)
+	}
+}
@@ -0,0 +1,117 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/model"
+	"review-assigner/internal/rest"
+	"review-assigner/internal/rest/payload"
+	"review-assigner/internal/service"
+	"review-assigner/internal/storage/memory"
+)
+
+const adminToken = "admin-secret"
+
+// newTokenServer creates router in token auth mode with team backend of u1 and u2.
+// It returns service, router and user token of u1.
+func newTokenServer(t *testing.T) (*service.Service, http.Handler, string) {
+	t.Helper()
+
+	svc, err := service.NewService(memory.New(), config.SelectionConfig{Strategy: "random"}, nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	ctx := service.WithIdentity(context.Background(), service.Identity{Admin: true})
+	_, err = svc.AddTeamAddUpdateUsers(ctx, &model.Team{
+		Name: "backend",
+		Members: []model.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddTeamAddUpdateUsers: %v", err)
+	}
+	issued, err := svc.IssueUserToken(ctx, "u1", "laptop")
+	if err != nil {
+		t.Fatalf("IssueUserToken: %v", err)
+	}
+
+	authenticator, err := rest.NewAuthenticator(config.AuthConfig{Mode: config.AuthModeToken, AdminToken: adminToken}, svc)
+	if err != nil {
+		t.Fatalf("NewAuthenticator: %v", err)
+	}
+	return svc, rest.NewRouter(svc, authenticator, config.WebhookConfig{}), issued.Token
+}
+
+// requireStatus sends GET request with headers to router and checks response status.
+func requireStatus(t *testing.T, router http.Handler, target string, headers map[string]string, want int) {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != want {
+		t.Fatalf("want status %d, got %d: %s", want, w.Code, w.Body)
+	}
+	if want < http.StatusBadRequest {
+		return
+	}
+	var response payload.ErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if response.Error.Code != payload.ErrCodeNOT_FOUND || response.Error.Message == "" {
+		t.Fatalf("unexpected error response %+v", response)
+	}
+}
+
+func TestAdminOnly(t *testing.T) {
+	_, router, userToken := newTokenServer(t)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{name: "missing token", want: http.StatusUnauthorized},
+		{name: "invalid admin token", headers: map[string]string{"X-Admin-Token": "wrong"}, want: http.StatusUnauthorized},
+		{name: "invalid user token", headers: map[string]string{"X-User-Token": "wrong"}, want: http.StatusUnauthorized},
+		{name: "user", headers: map[string]string{"X-User-Token": userToken}, want: http.StatusForbidden},
+		{name: "admin", headers: map[string]string{"X-Admin-Token": adminToken}, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requireStatus(t, router, "/stats", tt.headers, tt.want)
+		})
+	}
+}
+
+func TestAdminOrUser(t *testing.T) {
+	_, router, userToken := newTokenServer(t)
+
+	requireStatus(t, router, "/users/getReview?user_id=u1", nil, http.StatusUnauthorized)
+	requireStatus(t, router, "/users/getReview?user_id=u1", map[string]string{"X-User-Token": userToken}, http.StatusOK)
+	requireStatus(t, router, "/users/getReview?user_id=u1", map[string]string{"X-Admin-Token": adminToken}, http.StatusOK)
+}
+
+func TestInactiveUserToken(t *testing.T) {
+	svc, router, userToken := newTokenServer(t)
+	ctx := service.WithIdentity(context.Background(), service.Identity{Admin: true})
+	headers := map[string]string{"X-User-Token": userToken}
+
+	requireStatus(t, router, "/users/getReview?user_id=u1", headers, http.StatusOK)
+	if _, _, err := svc.SetUserActivity(ctx, "u1", false, false); err != nil {
+		t.Fatalf("SetUserActivity: %v", err)
+	}
+	requireStatus(t, router, "/users/getReview?user_id=u1", headers, http.StatusUnauthorized)
+}
@@ -44,10 +44,16 @@ func (h *Handler) AddTeamAddUpdateUsers(w http.ResponseWriter, r *http.Request)
 	}
 
 	team, err := h.service.AddTeamAddUpdateUsers(r.Context(), &model.Team{
-		Name:    req.Name,
-		Members: req.Members,
+		Name:              req.Name,
+		RequiredReviewers: req.RequiredReviewers,
+		Members:           req.Members,
+		Leads:             req.Leads,
 	})
 	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, err.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
 		var teamErr errs.TeamExistsError
 		if errors.As(err, &teamErr) {
 			slog.Warn("team already exists on add", "team_name", req.Name, "error", teamErr)
@@ -80,6 +86,11 @@ func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
 			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
 			return
 		}
+		var forbiddenErr errs.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			writeJSONError(w, forbiddenErr.Error(), http.StatusForbidden, payload.ErrCodeNOT_FOUND)
+			return
+		}
 		slog.Error("service failed to get team", "error", err)
 		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
 		return
@@ -88,6 +99,58 @@ func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	writeJSONResponse(w, team, http.StatusOK)
 }
 
+// SetTeamRequiredReviewers handles POST /team/setRequiredReviewers
+func (h *Handler) SetTeamRequiredReviewers(w http.ResponseWriter, r *http.Request) {
+	var req payload.TeamSetRequiredReviewersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	team, err := h.service.SetTeamRequiredReviewers(r.Context(), req.TeamName, req.RequiredReviewers)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to set team required reviewers", "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, map[string]*model.Team{"team": team}, http.StatusOK)
+}
+
+// SetTeamReviewSLA handles POST /team/setReviewSLA
+func (h *Handler) SetTeamReviewSLA(w http.ResponseWriter, r *http.Request) {
+	var req payload.TeamSetReviewSLARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	team, err := h.service.SetTeamReviewSLA(r.Context(), req.TeamName, req.ReviewSLASeconds, req.AutoReassign)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to set team review sla", "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, map[string]*model.Team{"team": team}, http.StatusOK)
+}
+
 // SetUserActivity handles POST /users/setIsActive
 func (h *Handler) SetUserActivity(w http.ResponseWriter, r *http.Request) {
 	var req payload.SetIsActiveRequest
@@ -100,18 +163,111 @@ func (h *Handler) SetUserActivity(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.service.SetUserActivity(r.Context(), req.UserID, req.IsActive)
+	user, report, err := h.service.SetUserActivity(r.Context(), req.UserID, req.IsActive, req.ReassignReviews)
 	if err != nil {
 		if errors.Is(err, errs.NotFoundErr) {
 			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
 			return
 		}
+		var forbiddenErr errs.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			writeJSONError(w, forbiddenErr.Error(), http.StatusForbidden, payload.ErrCodeNOT_FOUND)
+			return
+		}
 		slog.Error("service failed to set user activity", "error", err)
 		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
 		return
 	}
 
-	writeJSONResponse(w, map[string]*model.User{"user": user}, http.StatusOK)
+	writeJSONResponse(w, payload.SetIsActiveResponse{User: user, Reassignment: report}, http.StatusOK)
+}
+
+// DeactivateTeam handles POST /team/deactivate
+func (h *Handler) DeactivateTeam(w http.ResponseWriter, r *http.Request) {
+	var req payload.TeamDeactivateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	report, err := h.service.DeactivateTeam(r.Context(), req.TeamName)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to deactivate team", "team_name", req.TeamName, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, payload.TeamDeactivateResponse{TeamName: req.TeamName, Reassignment: report}, http.StatusOK)
+}
+
+// SetTeamLeads handles POST /team/setLeads
+func (h *Handler) SetTeamLeads(w http.ResponseWriter, r *http.Request) {
+	var req payload.TeamSetLeadsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	team, err := h.service.SetTeamLeads(r.Context(), req.TeamName, req.Leads)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, err.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		var forbiddenErr errs.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			writeJSONError(w, forbiddenErr.Error(), http.StatusForbidden, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to set team leads", "team_name", req.TeamName, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, map[string]*model.Team{"team": team}, http.StatusOK)
+}
+
+// AddTeamMembers handles POST /team/addMembers
+func (h *Handler) AddTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req payload.TeamAddMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	team, err := h.service.AddTeamMembers(r.Context(), req.TeamName, req.Members)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		var forbiddenErr errs.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			writeJSONError(w, forbiddenErr.Error(), http.StatusForbidden, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to add team members", "team_name", req.TeamName, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, map[string]*model.Team{"team": team}, http.StatusOK)
 }
 
 // CreatePullRequest handles POST /pullRequest/create
@@ -205,6 +361,11 @@ func (h *Handler) ReassignPullRequest(w http.ResponseWriter, r *http.Request) {
 			writeJSONError(w, errs.NoCandidateErr.Error(), http.StatusConflict, payload.ErrCodeNO_CANDIDATE)
 			return
 		}
+		var forbiddenErr errs.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			writeJSONError(w, forbiddenErr.Error(), http.StatusForbidden, payload.ErrCodeNOT_FOUND)
+			return
+		}
 		slog.Error("service failed to reassign pull request", "error", err)
 		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
 		return
@@ -213,6 +374,56 @@ func (h *Handler) ReassignPullRequest(w http.ResponseWriter, r *http.Request) {
 	writeJSONResponse(w, map[string]any{"pr": pr, "replaced_by": newReviewerID}, http.StatusOK)
 }
 
+// GetUnderstaffedPullRequests handles GET /pullRequest/understaffed
+func (h *Handler) GetUnderstaffedPullRequests(w http.ResponseWriter, r *http.Request) {
+	// team_name is optional, all teams are matched without it
+	teamName := r.URL.Query().Get("team_name")
+	if len(teamName) > 255 {
+		writeJSONError(w, "team_name cannot be longer than 255 symbols", http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	pullRequests, err := h.service.GetUnderstaffedPullRequests(r.Context(), teamName)
+	if err != nil {
+		slog.Error("service failed to get understaffed pull requests", "team_name", teamName, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, payload.GetUnderstaffedResponse{PullRequests: pullRequests}, http.StatusOK)
+}
+
+// GetPullRequestHistory handles GET /pullRequest/history
+func (h *Handler) GetPullRequestHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeJSONError(w, "missing query parameter 'pull_request_id'", http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if len(prID) > 255 {
+		writeJSONError(w, "pull_request_id cannot be longer than 255 symbols", http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	events, err := h.service.GetPullRequestHistory(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to get pull request history", "pull_request_id", prID, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	response := payload.GetPullRequestHistoryResponse{
+		PullRequestID: prID,
+		Events:        events,
+	}
+
+	writeJSONResponse(w, response, http.StatusOK)
+}
+
 // GetUserAssignments handles GET /users/getReview
 func (h *Handler) GetUserAssignments(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
@@ -231,6 +442,11 @@ func (h *Handler) GetUserAssignments(w http.ResponseWriter, r *http.Request) {
 			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
 			return
 		}
+		var forbiddenErr errs.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			writeJSONError(w, forbiddenErr.Error(), http.StatusForbidden, payload.ErrCodeNOT_FOUND)
+			return
+		}
 		slog.Error("service failed to get user assignments", "user_id", userID, "error", err)
 		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
 		return
@@ -244,6 +460,217 @@ func (h *Handler) GetUserAssignments(w http.ResponseWriter, r *http.Request) {
 	writeJSONResponse(w, response, http.StatusOK)
 }
 
+// GetStats handles GET /stats
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.GetStats(r.Context())
+	if err != nil {
+		slog.Error("service failed to get stats", "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, stats, http.StatusOK)
+}
+
+// IssueUserToken handles POST /users/issueToken
+func (h *Handler) IssueUserToken(w http.ResponseWriter, r *http.Request) {
+	var req payload.IssueUserTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	token, err := h.service.IssueUserToken(r.Context(), req.UserID, req.Name)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to issue user token", "user_id", req.UserID, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, payload.IssueUserTokenResponse{UserToken: token}, http.StatusCreated)
+}
+
+// GetUserTokens handles GET /users/getTokens
+func (h *Handler) GetUserTokens(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeJSONError(w, "missing query parameter 'user_id'", http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if len(userID) > 255 {
+		writeJSONError(w, "user_id cannot be longer than 255 symbols", http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	tokens, err := h.service.GetUserTokens(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to get user tokens", "user_id", userID, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	response := payload.GetUserTokensResponse{
+		UserID: userID,
+		Tokens: tokens,
+	}
+
+	writeJSONResponse(w, response, http.StatusOK)
+}
+
+// RevokeUserToken handles POST /users/revokeToken
+func (h *Handler) RevokeUserToken(w http.ResponseWriter, r *http.Request) {
+	var req payload.RevokeUserTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	token, err := h.service.RevokeUserToken(r.Context(), req.TokenID)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to revoke user token", "token_id", req.TokenID, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, payload.RevokeUserTokenResponse{UserToken: token}, http.StatusOK)
+}
+
+// SetExternalIdentity handles POST /users/setExternalIdentity
+func (h *Handler) SetExternalIdentity(w http.ResponseWriter, r *http.Request) {
+	var req payload.SetExternalIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	identity, err := h.service.SetExternalIdentity(r.Context(), model.ExternalIdentity{
+		Provider: req.Provider,
+		Login:    req.Login,
+		UserID:   req.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to set external identity", "user_id", req.UserID, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, payload.SetExternalIdentityResponse{Identity: identity}, http.StatusOK)
+}
+
+// GetExternalIdentities handles GET /users/getExternalIdentities
+func (h *Handler) GetExternalIdentities(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeJSONError(w, "missing query parameter 'user_id'", http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if len(userID) > 255 {
+		writeJSONError(w, "user_id cannot be longer than 255 symbols", http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	identities, err := h.service.GetExternalIdentities(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to get external identities", "user_id", userID, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	response := payload.GetExternalIdentitiesResponse{
+		UserID:     userID,
+		Identities: identities,
+	}
+
+	writeJSONResponse(w, response, http.StatusOK)
+}
+
+// DeleteExternalIdentity handles POST /users/deleteExternalIdentity
+func (h *Handler) DeleteExternalIdentity(w http.ResponseWriter, r *http.Request) {
+	var req payload.DeleteExternalIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	if err := h.service.DeleteExternalIdentity(r.Context(), req.Provider, req.Login); err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to delete external identity", "provider", req.Provider, "login", req.Login, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetDigestOptOut handles POST /users/setDigestOptOut
+func (h *Handler) SetDigestOptOut(w http.ResponseWriter, r *http.Request) {
+	var req payload.SetDigestOptOutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, invalidJsonBodyMsg, http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		writeJSONError(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	if err := h.service.SetDigestOptOut(r.Context(), req.UserID, req.OptOut); err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			writeJSONError(w, errs.NotFoundErr.Error(), http.StatusNotFound, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		var forbiddenErr errs.ForbiddenError
+		if errors.As(err, &forbiddenErr) {
+			writeJSONError(w, forbiddenErr.Error(), http.StatusForbidden, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		slog.Error("service failed to set digest opt out", "user_id", req.UserID, "error", err)
+		writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+		return
+	}
+
+	writeJSONResponse(w, payload.SetDigestOptOutResponse{UserID: req.UserID, OptOut: req.OptOut}, http.StatusOK)
+}
+
 func writeJSONError(w http.ResponseWriter, msg string, statusCode int, apiCode payload.ErrorCode) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
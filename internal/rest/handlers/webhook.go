@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/rest/payload"
+	"review-assigner/internal/service"
+	"review-assigner/internal/webhook"
+)
+
+// maxWebhookBodyBytes is maximum size of webhook payload, GitHub caps payloads at 25 MB.
+const maxWebhookBodyBytes = 25 << 20
+
+// Statuses of webhook processing.
+const (
+	webhookStatusCreated = "created"
+	webhookStatusMerged  = "merged"
+	webhookStatusIgnored = "ignored"
+)
+
+// WebhookHandler receives webhooks of code hosting and creates and merges pull requests accordingly.
+// Webhooks are authenticated by providers instead of api tokens.
+type WebhookHandler struct {
+	service *service.Service
+}
+
+func NewWebhookHandler(service *service.Service) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// Receive returns handler of POST /webhooks/{provider}
+func (h *WebhookHandler) Receive(provider webhook.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+		if err != nil {
+			writeJSONError(w, "failed to read body", http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+			return
+		}
+
+		if err := provider.Verify(r.Header, body); err != nil {
+			writeJSONError(w, err.Error(), http.StatusUnauthorized, payload.ErrCodeNOT_FOUND)
+			return
+		}
+
+		event, err := provider.Parse(r.Header, body)
+		if err != nil {
+			writeJSONError(w, err.Error(), http.StatusBadRequest, payload.ErrCodeNOT_FOUND)
+			return
+		}
+		if event == nil {
+			writeJSONResponse(w, payload.WebhookResponse{Status: webhookStatusIgnored, Reason: "event is not handled"}, http.StatusOK)
+			return
+		}
+
+		ctx := service.WithSystemIdentity(r.Context(), "webhook:"+provider.Name())
+		response, err := h.apply(ctx, provider.Name(), event)
+		if err != nil {
+			slog.Error("failed to apply webhook event", "provider", provider.Name(),
+				"pull_request_id", event.PullRequestID, "action", event.Action, "error", err)
+			writeJSONError(w, internalServerErrorMsg, http.StatusInternalServerError, payload.ErrCodeNOT_FOUND)
+			return
+		}
+
+		writeJSONResponse(w, response, http.StatusOK)
+	}
+}
+
+// apply creates or merges pull request of event. Events which can't be applied,
+// e.g. of authors without mapped users, are ignored so provider doesn't redeliver them.
+func (h *WebhookHandler) apply(ctx context.Context, provider string, event *webhook.Event) (payload.WebhookResponse, error) {
+	ignored := func(reason string) (payload.WebhookResponse, error) {
+		slog.Info("webhook event ignored", "provider", provider,
+			"pull_request_id", event.PullRequestID, "action", event.Action, "reason", reason)
+		return payload.WebhookResponse{Status: webhookStatusIgnored, PullRequestID: event.PullRequestID, Reason: reason}, nil
+	}
+
+	switch event.Action {
+	case webhook.ActionOpen:
+		if event.AuthorLogin == "" {
+			return ignored("author is unknown")
+		}
+
+		author, err := h.service.ResolveExternalIdentity(ctx, provider, event.AuthorLogin)
+		if err != nil {
+			if errors.Is(err, errs.NotFoundErr) {
+				return ignored(fmt.Sprintf("%s login %s is not mapped to user", provider, event.AuthorLogin))
+			}
+			return payload.WebhookResponse{}, err
+		}
+
+		_, err = h.service.CreatePullRequest(ctx, &model.PullRequestShort{
+			Id:       event.PullRequestID,
+			Name:     event.Title,
+			AuthorID: author.Id,
+		})
+		if err != nil {
+			// reopened pull requests and redelivered events are already created
+			var prErr errs.PullRequestExistsError
+			if errors.As(err, &prErr) {
+				return ignored(prErr.Error())
+			}
+			if errors.Is(err, errs.NotFoundErr) {
+				return ignored("team of author not found")
+			}
+			return payload.WebhookResponse{}, err
+		}
+
+		return payload.WebhookResponse{Status: webhookStatusCreated, PullRequestID: event.PullRequestID}, nil
+
+	case webhook.ActionMerge:
+		if _, err := h.service.MergePullRequest(ctx, event.PullRequestID); err != nil {
+			if errors.Is(err, errs.NotFoundErr) {
+				return ignored("pull request not found")
+			}
+			return payload.WebhookResponse{}, err
+		}
+
+		return payload.WebhookResponse{Status: webhookStatusMerged, PullRequestID: event.PullRequestID}, nil
+	}
+
+	return ignored("action is not handled")
+}
@@ -18,10 +18,57 @@ const (
 // Validation is applied via embedded model.Team structure.
 type TeamAddRequest model.Team
 
+// TeamSetRequiredReviewersRequest corresponds to the /team/setRequiredReviewers POST request body.
+type TeamSetRequiredReviewersRequest struct {
+	TeamName          string `json:"team_name" validate:"required,max=255"`
+	RequiredReviewers int    `json:"required_reviewers" validate:"required,min=1"`
+}
+
+// TeamSetReviewSLARequest corresponds to the /team/setReviewSLA POST request body.
+// Zero ReviewSLASeconds disables escalation of team pull requests.
+type TeamSetReviewSLARequest struct {
+	TeamName         string `json:"team_name" validate:"required,max=255"`
+	ReviewSLASeconds int    `json:"review_sla_seconds" validate:"min=0"`
+	AutoReassign     bool   `json:"auto_reassign"`
+}
+
 // SetIsActiveRequest corresponds to the /users/setIsActive POST request body.
+// ReassignReviews only has effect on deactivation.
 type SetIsActiveRequest struct {
-	UserID   string `json:"user_id" validate:"required,max=255"`
-	IsActive bool   `json:"is_active"`
+	UserID          string `json:"user_id" validate:"required,max=255"`
+	IsActive        bool   `json:"is_active"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+// SetIsActiveResponse corresponds to the /users/setIsActive POST response.
+// Reassignment is present only if reviews were reassigned.
+type SetIsActiveResponse struct {
+	User         *model.User               `json:"user"`
+	Reassignment *model.ReassignmentReport `json:"reassignment,omitempty"`
+}
+
+// TeamSetLeadsRequest corresponds to the /team/setLeads POST request body.
+// Empty Leads removes all leads of team.
+type TeamSetLeadsRequest struct {
+	TeamName string   `json:"team_name" validate:"required,max=255"`
+	Leads    []string `json:"leads" validate:"dive,required,max=255"`
+}
+
+// TeamAddMembersRequest corresponds to the /team/addMembers POST request body.
+type TeamAddMembersRequest struct {
+	TeamName string             `json:"team_name" validate:"required,max=255"`
+	Members  []model.TeamMember `json:"members" validate:"required,min=1,dive"`
+}
+
+// TeamDeactivateRequest corresponds to the /team/deactivate POST request body.
+type TeamDeactivateRequest struct {
+	TeamName string `json:"team_name" validate:"required,max=255"`
+}
+
+// TeamDeactivateResponse corresponds to the /team/deactivate POST response.
+type TeamDeactivateResponse struct {
+	TeamName     string                    `json:"team_name"`
+	Reassignment *model.ReassignmentReport `json:"reassignment"`
 }
 
 // PullRequestCreateRequest corresponds to the /pullRequest/create POST request body.
@@ -49,6 +96,88 @@ type GetUserReviewResponse struct {
 	PullRequests []model.PullRequestShort `json:"pull_requests"`
 }
 
+// GetUnderstaffedResponse corresponds to the /pullRequest/understaffed GET response.
+type GetUnderstaffedResponse struct {
+	PullRequests []model.PullRequest `json:"pull_requests"`
+}
+
+// GetPullRequestHistoryResponse corresponds to the /pullRequest/history GET response.
+type GetPullRequestHistoryResponse struct {
+	PullRequestID string                  `json:"pull_request_id"`
+	Events        []model.AssignmentEvent `json:"events"`
+}
+
+// IssueUserTokenRequest corresponds to the /users/issueToken POST request body.
+type IssueUserTokenRequest struct {
+	UserID string `json:"user_id" validate:"required,max=255"`
+	Name   string `json:"name" validate:"required,max=255"`
+}
+
+// IssueUserTokenResponse corresponds to the /users/issueToken POST response.
+type IssueUserTokenResponse struct {
+	UserToken *model.IssuedUserToken `json:"user_token"`
+}
+
+// GetUserTokensResponse corresponds to the /users/getTokens GET response.
+type GetUserTokensResponse struct {
+	UserID string            `json:"user_id"`
+	Tokens []model.UserToken `json:"tokens"`
+}
+
+// RevokeUserTokenRequest corresponds to the /users/revokeToken POST request body.
+type RevokeUserTokenRequest struct {
+	TokenID int64 `json:"token_id" validate:"required,min=1"`
+}
+
+// RevokeUserTokenResponse corresponds to the /users/revokeToken POST response.
+type RevokeUserTokenResponse struct {
+	UserToken *model.UserToken `json:"user_token"`
+}
+
+// SetExternalIdentityRequest corresponds to the /users/setExternalIdentity POST request body.
+type SetExternalIdentityRequest struct {
+	UserID   string `json:"user_id" validate:"required,max=255"`
+	Provider string `json:"provider" validate:"required,oneof=github gitlab"`
+	Login    string `json:"login" validate:"required,max=255"`
+}
+
+// SetExternalIdentityResponse corresponds to the /users/setExternalIdentity POST response.
+type SetExternalIdentityResponse struct {
+	Identity *model.ExternalIdentity `json:"identity"`
+}
+
+// GetExternalIdentitiesResponse corresponds to the /users/getExternalIdentities GET response.
+type GetExternalIdentitiesResponse struct {
+	UserID     string                   `json:"user_id"`
+	Identities []model.ExternalIdentity `json:"identities"`
+}
+
+// DeleteExternalIdentityRequest corresponds to the /users/deleteExternalIdentity POST request body.
+type DeleteExternalIdentityRequest struct {
+	Provider string `json:"provider" validate:"required,oneof=github gitlab"`
+	Login    string `json:"login" validate:"required,max=255"`
+}
+
+// SetDigestOptOutRequest corresponds to the /users/setDigestOptOut POST request body.
+type SetDigestOptOutRequest struct {
+	UserID string `json:"user_id" validate:"required,max=255"`
+	OptOut bool   `json:"opt_out"`
+}
+
+// SetDigestOptOutResponse corresponds to the /users/setDigestOptOut POST response.
+type SetDigestOptOutResponse struct {
+	UserID string `json:"user_id"`
+	OptOut bool   `json:"opt_out"`
+}
+
+// WebhookResponse is returned to code hosting on webhook delivery.
+// Status is one of "created", "merged" and "ignored", Reason is set for ignored events.
+type WebhookResponse struct {
+	Status        string `json:"status"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
 // InnerError represents the nested 'error' object in the response.
 // Corresponds to the inner object of #/components/schemas/ErrorResponse.
 type InnerError struct {
@@ -3,20 +3,60 @@ package rest
 import (
 	"net/http"
 
+	"github.com/go-playground/validator/v10"
+
+	"review-assigner/internal/config"
 	"review-assigner/internal/rest/handlers"
+	"review-assigner/internal/service"
+	"review-assigner/internal/webhook"
 )
 
-func NewRouter(s *service.Service) *http.ServeMux {
-	h := handlers.NewHandler(s)
+func NewRouter(s *service.Service, authenticator Authenticator, webhookCfg config.WebhookConfig) *http.ServeMux {
+	h := handlers.NewHandler(s, validator.New(validator.WithRequiredStructEnabled()))
+	a := &auth{authenticator: authenticator}
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("POST /team/add", h.AddTeamAddUpdateUsers)
-	mux.HandleFunc("GET /team/get", h.GetTeam)
-	mux.HandleFunc("POST /users/setIsActive", h.SetUserActivity)
-	mux.HandleFunc("POST /pullRequest/create", h.CreatePullRequest)
-	mux.HandleFunc("POST /pullRequest/merge", h.MergePullRequest)
-	mux.HandleFunc("POST /pullRequest/reassign", h.ReassignPullRequest)
-	mux.HandleFunc("GET /users/getReview", h.GetUserAssignments)
+	mux.HandleFunc("POST /team/add", a.adminOnly(h.AddTeamAddUpdateUsers))
+	mux.HandleFunc("GET /team/get", a.adminOrUser(h.GetTeam))
+	mux.HandleFunc("POST /team/setRequiredReviewers", a.adminOnly(h.SetTeamRequiredReviewers))
+	mux.HandleFunc("POST /team/setReviewSLA", a.adminOnly(h.SetTeamReviewSLA))
+	mux.HandleFunc("POST /team/deactivate", a.adminOnly(h.DeactivateTeam))
+	mux.HandleFunc("POST /team/setLeads", a.adminOnly(h.SetTeamLeads))
+	// team leads are allowed to manage their own team, see service permissions
+	mux.HandleFunc("POST /team/addMembers", a.adminOrUser(h.AddTeamMembers))
+	mux.HandleFunc("POST /users/setIsActive", a.adminOrUser(h.SetUserActivity))
+	mux.HandleFunc("POST /pullRequest/create", a.adminOnly(h.CreatePullRequest))
+	mux.HandleFunc("POST /pullRequest/merge", a.adminOnly(h.MergePullRequest))
+	mux.HandleFunc("POST /pullRequest/reassign", a.adminOrUser(h.ReassignPullRequest))
+	mux.HandleFunc("GET /pullRequest/understaffed", a.adminOnly(h.GetUnderstaffedPullRequests))
+	mux.HandleFunc("GET /pullRequest/history", a.adminOnly(h.GetPullRequestHistory))
+	mux.HandleFunc("GET /users/getReview", a.adminOrUser(h.GetUserAssignments))
+	mux.HandleFunc("POST /users/issueToken", a.adminOnly(h.IssueUserToken))
+	mux.HandleFunc("GET /users/getTokens", a.adminOnly(h.GetUserTokens))
+	mux.HandleFunc("POST /users/revokeToken", a.adminOnly(h.RevokeUserToken))
+	mux.HandleFunc("POST /users/setExternalIdentity", a.adminOnly(h.SetExternalIdentity))
+	mux.HandleFunc("GET /users/getExternalIdentities", a.adminOnly(h.GetExternalIdentities))
+	mux.HandleFunc("POST /users/deleteExternalIdentity", a.adminOnly(h.DeleteExternalIdentity))
+	mux.HandleFunc("POST /users/setDigestOptOut", a.adminOrUser(h.SetDigestOptOut))
+	mux.HandleFunc("GET /stats", a.adminOnly(h.GetStats))
+
+	// webhooks are authenticated by providers, e.g. with signatures of deliveries
+	wh := handlers.NewWebhookHandler(s)
+	for _, provider := range webhookProviders(webhookCfg) {
+		mux.HandleFunc("POST /webhooks/"+provider.Name(), wh.Receive(provider))
+	}
 
 	return mux
 }
+
+// webhookProviders returns providers which have secrets configured.
+func webhookProviders(cfg config.WebhookConfig) []webhook.Provider {
+	var providers []webhook.Provider
+	if cfg.GitHubSecret != "" {
+		providers = append(providers, webhook.NewGitHub(cfg.GitHubSecret))
+	}
+	if cfg.GitLabToken != "" {
+		providers = append(providers, webhook.NewGitLab(cfg.GitLabToken))
+	}
+	return providers
+}
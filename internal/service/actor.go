@@ -0,0 +1,25 @@
+package service
+
+import "context"
+
+const (
+	// SystemActor is recorded in assignment events when context carries no actor.
+	SystemActor = "system"
+	// AdminActor is recorded in assignment events for requests authorized with admin token.
+	AdminActor = "admin"
+)
+
+type actorKey struct{}
+
+// WithActor returns context carrying identity of whoever performs the operation.
+// It is recorded in assignment events.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"review-assigner/internal/model"
+)
+
+// SetDigestOptOut unsubscribes user from email digests or subscribes back.
+// It is allowed to admins, user itself and leads of user's team.
+func (s *Service) SetDigestOptOut(ctx context.Context, userID string, optOut bool) error {
+	if err := s.authorizeUser(ctx, userID, "change digest subscription of "+userID); err != nil {
+		return err
+	}
+
+	if err := s.storage.SetDigestOptOut(ctx, userID, optOut); err != nil {
+		return fmt.Errorf("storage failed to set digest opt out: %w", err)
+	}
+	return nil
+}
+
+// GetDigests returns digests of subscribed users who have open pull requests to review.
+// Users without email, inactive users and users without pending reviews get no digest.
+func (s *Service) GetDigests(ctx context.Context, now time.Time) ([]model.Digest, error) {
+	if err := authorizeAdmin(ctx, "send digests"); err != nil {
+		return nil, err
+	}
+
+	recipients, err := s.storage.GetDigestRecipients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get digest recipients: %w", err)
+	}
+
+	var digests []model.Digest
+	for _, user := range recipients {
+		reviews, err := s.getPendingReviews(ctx, user.Id, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(reviews) == 0 {
+			continue
+		}
+
+		digests = append(digests, model.Digest{User: user, Reviews: reviews, CreatedAt: now})
+	}
+
+	return digests, nil
+}
+
+// ClaimDigest records that digest of user is sent now, unless it was already sent not before since,
+// e.g. by another instance. It returns false if digest must not be sent.
+func (s *Service) ClaimDigest(ctx context.Context, userID string, now, since time.Time) (bool, error) {
+	if err := authorizeAdmin(ctx, "send digests"); err != nil {
+		return false, err
+	}
+
+	claimed, err := s.storage.ClaimDigest(ctx, userID, now, since)
+	if err != nil {
+		return false, fmt.Errorf("storage failed to claim digest: %w", err)
+	}
+	return claimed, nil
+}
+
+// getPendingReviews returns open pull requests user reviews, oldest first.
+func (s *Service) getPendingReviews(ctx context.Context, userID string, now time.Time) ([]model.PendingReview, error) {
+	assignments, err := s.GetUserAssignments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []model.PendingReview
+	for _, shortPR := range assignments {
+		if shortPR.Status != model.PullRequestStatusOPEN {
+			continue
+		}
+
+		// short pull request lacks creation time
+		pr, err := s.storage.GetPullRequest(ctx, shortPR.Id)
+		if err != nil {
+			return nil, fmt.Errorf("storage failed to get pull request: %w", err)
+		}
+		createdAt := now
+		if pr.CreatedAt != nil {
+			createdAt = *pr.CreatedAt
+		}
+
+		reviews = append(reviews, model.PendingReview{
+			PullRequest: shortPR,
+			CreatedAt:   createdAt,
+			Age:         now.Sub(createdAt),
+		})
+	}
+
+	slices.SortStableFunc(reviews, func(a, b model.PendingReview) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return reviews, nil
+}
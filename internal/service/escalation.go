@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// SetTeamReviewSLA updates review SLA of team. Zero slaSeconds disables escalation of its pull requests.
+// If autoReassign is set, reviewers of escalated pull requests are replaced.
+func (s *Service) SetTeamReviewSLA(ctx context.Context, name string, slaSeconds int, autoReassign bool) (*model.Team, error) {
+	if err := authorizeAdmin(ctx, "set review sla"); err != nil {
+		return nil, err
+	}
+
+	var result *model.Team
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.storage.SetTeamReviewSLA(ctx, name, slaSeconds, autoReassign); err != nil {
+			return fmt.Errorf("storage failed to set team review sla: %w", err)
+		}
+
+		var err error
+		result, err = s.storage.GetTeam(ctx, name)
+		if err != nil {
+			return fmt.Errorf("storage failed to get team: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EscalateStalePullRequests escalates open pull requests which were not merged within review SLA
+// of author's team. Escalation is recorded in history of pull request, and notifications are sent about it.
+// If the team enables auto reassign, every reviewer of pull request is replaced with a member
+// who didn't review it yet, see ReassignPullRequest.
+//
+// Pull request is escalated once, even by concurrent instances. Pull requests are escalated
+// in separate transactions, so failure of one doesn't prevent escalation of others.
+// Successful escalations are returned together with errors of failed ones.
+func (s *Service) EscalateStalePullRequests(ctx context.Context, now time.Time) ([]model.Escalation, error) {
+	if err := authorizeAdmin(ctx, "escalate stale pull requests"); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.storage.GetStalePullRequestIDs(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get stale pull requests: %w", err)
+	}
+
+	var (
+		result  []model.Escalation
+		escErrs []error
+	)
+	for _, id := range ids {
+		escalation, err := s.escalatePullRequest(ctx, id, now)
+		if err != nil {
+			escErrs = append(escErrs, fmt.Errorf("failed to escalate pull request %s: %w", id, err))
+			continue
+		}
+		if escalation != nil {
+			result = append(result, *escalation)
+		}
+	}
+
+	return result, errors.Join(escErrs...)
+}
+
+// escalatePullRequest escalates pull request in transaction.
+// Nil is returned if pull request is merged or already escalated meanwhile.
+func (s *Service) escalatePullRequest(ctx context.Context, id string, now time.Time) (*model.Escalation, error) {
+	var result *model.Escalation
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		pr, err := s.storage.GetPullRequest(ctx, id)
+		if err != nil {
+			return fmt.Errorf("storage failed to get pull request: %w", err)
+		}
+		if pr.Status != model.PullRequestStatusOPEN {
+			return nil
+		}
+
+		// team is resolved before escalation is claimed, so failure doesn't leave it half done.
+		// Pull request of author without team is still escalated, but reviewers are not replaced.
+		autoReassign := false
+		team, err := s.getAuthorTeam(ctx, pr.AuthorID)
+		switch {
+		case errors.Is(err, errs.NotFoundErr):
+			slog.Warn("escalating pull request without auto reassign, author team is not found",
+				"pull_request_id", pr.Id, "author_id", pr.AuthorID)
+		case err != nil:
+			return err
+		default:
+			autoReassign = team.ReviewSLAAutoReassign
+		}
+
+		added, err := s.storage.AddPullRequestEscalation(ctx, id, now)
+		if err != nil {
+			return fmt.Errorf("storage failed to add pull request escalation: %w", err)
+		}
+		if !added {
+			return nil
+		}
+
+		err = s.recordEvents(ctx, model.AssignmentEvent{
+			PullRequestID: pr.Id,
+			Type:          model.AssignmentEventESCALATED,
+			Reason:        model.ReasonReviewSLAExceeded,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return err
+		}
+
+		escalation := &model.Escalation{
+			PullRequestID: pr.Id,
+			EscalatedAt:   now,
+			Reassigned:    []model.Reassignment{},
+			NoCandidate:   []model.Reassignment{},
+		}
+		if !autoReassign {
+			result = escalation
+			return nil
+		}
+
+		// reviewers who let pull request go stale are not picked to replace each other
+		staleReviewers := slices.Clone(pr.AssignedReviewers)
+		for _, reviewerID := range staleReviewers {
+			reassignment, err := s.replaceReviewer(ctx, pr, reviewerID, nil, model.ReasonReviewSLAExceeded, staleReviewers)
+			if errors.Is(err, errs.NoCandidateErr) {
+				escalation.NoCandidate = append(escalation.NoCandidate, model.Reassignment{
+					PullRequestID: pr.Id,
+					OldReviewerID: reviewerID,
+				})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			escalation.Reassigned = append(escalation.Reassigned, reassignment)
+		}
+
+		result = escalation
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"review-assigner/internal/model"
+)
+
+func TestEscalateStalePullRequests(t *testing.T) {
+	svc := newMemoryService(t)
+	ctx := adminContext()
+	addTeam(t, svc, "backend", 2, "u1", "u2", "u3", "u4", "u5")
+	addTeam(t, svc, "frontend", 1, "f1", "f2")
+
+	if _, err := svc.SetTeamReviewSLA(ctx, "backend", 3600, true); err != nil {
+		t.Fatalf("SetTeamReviewSLA: %v", err)
+	}
+	if _, err := svc.SetTeamReviewSLA(ctx, "frontend", 3600, false); err != nil {
+		t.Fatalf("SetTeamReviewSLA: %v", err)
+	}
+
+	backendPR, err := svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr1", Name: "Add login", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if _, err := svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr2", Name: "Fix layout", AuthorID: "f1"}); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	// nothing is stale within SLA
+	escalations, err := svc.EscalateStalePullRequests(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("EscalateStalePullRequests: %v", err)
+	}
+	if len(escalations) != 0 {
+		t.Fatalf("want no escalations within SLA, got %+v", escalations)
+	}
+
+	later := time.Now().Add(2 * time.Hour)
+	escalations, err = svc.EscalateStalePullRequests(ctx, later)
+	if err != nil {
+		t.Fatalf("EscalateStalePullRequests: %v", err)
+	}
+	if len(escalations) != 2 {
+		t.Fatalf("want 2 escalations, got %+v", escalations)
+	}
+	slices.SortFunc(escalations, func(a, b model.Escalation) int {
+		return strings.Compare(a.PullRequestID, b.PullRequestID)
+	})
+
+	// backend replaces both stale reviewers with the two remaining members
+	backend := escalations[0]
+	if backend.PullRequestID != "pr1" || len(backend.Reassigned) != 2 || len(backend.NoCandidate) != 0 {
+		t.Fatalf("unexpected backend escalation %+v", backend)
+	}
+	for _, reassignment := range backend.Reassigned {
+		if !slices.Contains(backendPR.AssignedReviewers, reassignment.OldReviewerID) ||
+			slices.Contains(backendPR.AssignedReviewers, reassignment.NewReviewerID) || reassignment.NewReviewerID == "u1" {
+			t.Fatalf("unexpected reassignment %+v of reviewers %v", reassignment, backendPR.AssignedReviewers)
+		}
+	}
+
+	// frontend doesn't auto reassign
+	frontend := escalations[1]
+	if frontend.PullRequestID != "pr2" || len(frontend.Reassigned) != 0 || len(frontend.NoCandidate) != 0 {
+		t.Fatalf("unexpected frontend escalation %+v", frontend)
+	}
+
+	history, err := svc.GetPullRequestHistory(ctx, "pr2")
+	if err != nil {
+		t.Fatalf("GetPullRequestHistory: %v", err)
+	}
+	last := history[len(history)-1]
+	if last.Type != model.AssignmentEventESCALATED || last.Reason != model.ReasonReviewSLAExceeded {
+		t.Fatalf("unexpected last event %+v", last)
+	}
+
+	// pull request is escalated once
+	escalations, err = svc.EscalateStalePullRequests(ctx, later.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("EscalateStalePullRequests: %v", err)
+	}
+	if len(escalations) != 0 {
+		t.Fatalf("want no repeated escalations, got %+v", escalations)
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"review-assigner/internal/model"
+)
+
+// SetExternalIdentity maps login in external system to user.
+// Login already mapped to another user is remapped.
+func (s *Service) SetExternalIdentity(ctx context.Context, identity model.ExternalIdentity) (*model.ExternalIdentity, error) {
+	if err := authorizeAdmin(ctx, "manage external identities"); err != nil {
+		return nil, err
+	}
+
+	identity.Login = normalizeLogin(identity.Login)
+	if err := s.storage.SetExternalIdentity(ctx, identity); err != nil {
+		return nil, fmt.Errorf("storage failed to set external identity: %w", err)
+	}
+	return &identity, nil
+}
+
+// GetExternalIdentities lists identities of user in external systems.
+func (s *Service) GetExternalIdentities(ctx context.Context, userID string) ([]model.ExternalIdentity, error) {
+	if err := authorizeAdmin(ctx, "manage external identities"); err != nil {
+		return nil, err
+	}
+
+	var result []model.ExternalIdentity
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		// distinguish missing user from user without identities
+		if _, err := s.storage.GetUser(ctx, userID); err != nil {
+			return fmt.Errorf("storage failed to get user: %w", err)
+		}
+
+		var err error
+		result, err = s.storage.GetExternalIdentities(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("storage failed to get external identities: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteExternalIdentity removes mapping of login to user.
+func (s *Service) DeleteExternalIdentity(ctx context.Context, provider, login string) error {
+	if err := authorizeAdmin(ctx, "manage external identities"); err != nil {
+		return err
+	}
+
+	if err := s.storage.DeleteExternalIdentity(ctx, provider, normalizeLogin(login)); err != nil {
+		return fmt.Errorf("storage failed to delete external identity: %w", err)
+	}
+	return nil
+}
+
+// ResolveExternalIdentity returns user mapped to login in external system.
+func (s *Service) ResolveExternalIdentity(ctx context.Context, provider, login string) (*model.User, error) {
+	if err := authorizeAdmin(ctx, "resolve external identities"); err != nil {
+		return nil, err
+	}
+
+	user, err := s.storage.GetUserByExternalIdentity(ctx, provider, normalizeLogin(login))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get user by external identity: %w", err)
+	}
+	return user, nil
+}
+
+// normalizeLogin lowercases login as GitHub and GitLab logins are case-insensitive.
+func normalizeLogin(login string) string {
+	return strings.ToLower(login)
+}
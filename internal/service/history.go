@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"review-assigner/internal/model"
+)
+
+// GetPullRequestHistory returns review assignment history of pull request, oldest events first.
+func (s *Service) GetPullRequestHistory(ctx context.Context, prID string) ([]model.AssignmentEvent, error) {
+	if err := authorizeAdmin(ctx, "read pull request history"); err != nil {
+		return nil, err
+	}
+
+	var result []model.AssignmentEvent
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		// distinguish missing pull request from empty history
+		if _, err := s.storage.GetPullRequest(ctx, prID); err != nil {
+			return fmt.Errorf("storage failed to get pull request: %w", err)
+		}
+
+		var err error
+		result, err = s.storage.GetAssignmentEvents(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("storage failed to get assignment events: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// recordEvents appends events to history and enqueues notifications about them.
+// Actor is taken from context, and creation time defaults to now.
+func (s *Service) recordEvents(ctx context.Context, events ...model.AssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	actor := actorFromContext(ctx)
+	now := time.Now()
+	for i := range events {
+		events[i].Actor = actor
+		if events[i].CreatedAt.IsZero() {
+			events[i].CreatedAt = now
+		}
+	}
+
+	if err := s.storage.AddAssignmentEvents(ctx, events); err != nil {
+		return fmt.Errorf("storage failed to add assignment events: %w", err)
+	}
+	return s.enqueueNotifications(ctx, events)
+}
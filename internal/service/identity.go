@@ -0,0 +1,36 @@
+package service
+
+import "context"
+
+// Identity is authenticated caller of the service.
+type Identity struct {
+	// UserID is empty for admin authenticated with admin token.
+	UserID string
+	Admin  bool
+}
+
+type identityKey struct{}
+
+// WithIdentity returns context carrying identity of the caller.
+// Identity is also used as actor of assignment events, see WithActor.
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	actor := identity.UserID
+	if actor == "" && identity.Admin {
+		actor = AdminActor
+	}
+	ctx = WithActor(ctx, actor)
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns identity set with WithIdentity.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// WithSystemIdentity returns context of background job or webhook, which is recorded as actor
+// of assignment events. System is allowed everything, like admin.
+func WithSystemIdentity(ctx context.Context, actor string) context.Context {
+	ctx = WithActor(ctx, actor)
+	return context.WithValue(ctx, identityKey{}, Identity{Admin: true})
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"review-assigner/internal/model"
+)
+
+// notificationTypes maps assignment events to notifications sent about them.
+// Reviewers removed without replacement are not notified.
+var notificationTypes = map[model.AssignmentEventType]model.NotificationType{
+	model.AssignmentEventASSIGNED:   model.NotificationReviewAssigned,
+	model.AssignmentEventREASSIGNED: model.NotificationReviewReassigned,
+	model.AssignmentEventMERGED:     model.NotificationPullRequestMerged,
+	model.AssignmentEventESCALATED:  model.NotificationReviewEscalated,
+}
+
+// enqueueNotifications adds notifications about events to outbox, one message per sink.
+// It is called in the transaction recording events, so notifications are sent if and only if
+// the change is committed.
+func (s *Service) enqueueNotifications(ctx context.Context, events []model.AssignmentEvent) error {
+	if len(s.outboxSinks) == 0 {
+		return nil
+	}
+
+	pullRequests := make(map[string]model.PullRequestShort)
+	var messages []model.OutboxMessage
+	for _, event := range events {
+		notificationType, ok := notificationTypes[event.Type]
+		if !ok {
+			continue
+		}
+
+		pr, ok := pullRequests[event.PullRequestID]
+		if !ok {
+			fullPR, err := s.storage.GetPullRequest(ctx, event.PullRequestID)
+			if err != nil {
+				return fmt.Errorf("storage failed to get pull request: %w", err)
+			}
+			pr = model.PullRequestShort{Id: fullPR.Id, Name: fullPR.Name, AuthorID: fullPR.AuthorID, Status: fullPR.Status}
+			pullRequests[event.PullRequestID] = pr
+		}
+
+		id, err := newNotificationID()
+		if err != nil {
+			return err
+		}
+
+		notification := model.Notification{
+			ID:            id,
+			Type:          notificationType,
+			PullRequest:   pr,
+			OldReviewerID: event.OldReviewerID,
+			NewReviewerID: event.NewReviewerID,
+			Reason:        event.Reason,
+			Actor:         event.Actor,
+			CreatedAt:     event.CreatedAt,
+		}
+		payload, err := json.Marshal(notification)
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification: %w", err)
+		}
+		for _, sink := range s.outboxSinks {
+			messages = append(messages, model.OutboxMessage{
+				Sink:          sink,
+				Type:          string(notificationType),
+				Payload:       payload,
+				Status:        model.OutboxMessagePENDING,
+				NextAttemptAt: event.CreatedAt,
+				CreatedAt:     event.CreatedAt,
+			})
+		}
+	}
+
+	if err := s.storage.AddOutboxMessages(ctx, messages); err != nil {
+		return fmt.Errorf("storage failed to add outbox messages: %w", err)
+	}
+	return nil
+}
+
+func newNotificationID() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate notification id: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
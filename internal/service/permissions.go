@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// Operations are authorized by identity in context, see WithIdentity.
+// Context without identity is allowed nothing, background jobs and webhooks use WithSystemIdentity.
+
+// authorizeAdmin allows admins only.
+func authorizeAdmin(ctx context.Context, action string) error {
+	identity, ok := IdentityFromContext(ctx)
+	if ok && identity.Admin {
+		return nil
+	}
+	return errs.ForbiddenError{UserID: identity.UserID, Action: action}
+}
+
+// authorizeTeamLead allows admins and leads of team.
+func (s *Service) authorizeTeamLead(ctx context.Context, teamName string) error {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return errs.ForbiddenError{Action: "manage team " + teamName}
+	}
+	if identity.Admin {
+		return nil
+	}
+
+	isLead, err := s.isTeamLead(ctx, identity.UserID, teamName)
+	if err != nil {
+		return err
+	}
+	if !isLead {
+		return errs.ForbiddenError{UserID: identity.UserID, Action: "manage team " + teamName}
+	}
+	return nil
+}
+
+// authorizeTeamMember allows admins and members of team.
+func (s *Service) authorizeTeamMember(ctx context.Context, teamName string) error {
+	identity, ok := IdentityFromContext(ctx)
+	if ok && identity.Admin {
+		return nil
+	}
+	forbidden := errs.ForbiddenError{UserID: identity.UserID, Action: "read team " + teamName}
+	if identity.UserID == "" {
+		return forbidden
+	}
+
+	user, err := s.storage.GetUser(ctx, identity.UserID)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			return forbidden
+		}
+		return fmt.Errorf("storage failed to get user: %w", err)
+	}
+	if user.TeamName != teamName {
+		return forbidden
+	}
+	return nil
+}
+
+// authorizeUser allows admins, user itself and leads of user's team.
+func (s *Service) authorizeUser(ctx context.Context, userID, action string) error {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return errs.ForbiddenError{Action: action}
+	}
+	if identity.Admin || (identity.UserID != "" && identity.UserID == userID) {
+		return nil
+	}
+
+	user, err := s.storage.GetUser(ctx, userID)
+	if err != nil && !errors.Is(err, errs.NotFoundErr) {
+		return fmt.Errorf("storage failed to get user: %w", err)
+	}
+	if user != nil {
+		isLead, err := s.isTeamLead(ctx, identity.UserID, user.TeamName)
+		if err != nil {
+			return err
+		}
+		if isLead {
+			return nil
+		}
+	}
+
+	return errs.ForbiddenError{UserID: identity.UserID, Action: action}
+}
+
+// getPullRequestAsLead returns pull request if caller is admin or lead of team of its author.
+// Other callers get ForbiddenError whether pull request exists or not, so they can't probe which ones exist.
+func (s *Service) getPullRequestAsLead(ctx context.Context, id, action string) (*model.PullRequest, error) {
+	identity, ok := IdentityFromContext(ctx)
+	forbidden := errs.ForbiddenError{UserID: identity.UserID, Action: action}
+	if !ok {
+		return nil, forbidden
+	}
+
+	pr, err := s.storage.GetPullRequest(ctx, id)
+	if err != nil {
+		if !identity.Admin && errors.Is(err, errs.NotFoundErr) {
+			return nil, forbidden
+		}
+		return nil, fmt.Errorf("storage failed to get pull request: %w", err)
+	}
+	if identity.Admin {
+		return pr, nil
+	}
+
+	author, err := s.storage.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get author: %w", err)
+	}
+	isLead, err := s.isTeamLead(ctx, identity.UserID, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if !isLead {
+		return nil, forbidden
+	}
+	return pr, nil
+}
+
+// isTeamLead reports whether user is lead of team.
+// Lead who moved to another team doesn't manage former team anymore.
+func (s *Service) isTeamLead(ctx context.Context, userID, teamName string) (bool, error) {
+	if userID == "" {
+		return false, nil
+	}
+
+	leads, err := s.storage.GetTeamLeads(ctx, teamName)
+	if err != nil {
+		return false, fmt.Errorf("storage failed to get team leads: %w", err)
+	}
+	if !slices.Contains(leads, userID) {
+		return false, nil
+	}
+
+	user, err := s.storage.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.NotFoundErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("storage failed to get user: %w", err)
+	}
+
+	return user.TeamName == teamName, nil
+}
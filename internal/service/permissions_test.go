@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/service"
+)
+
+// newLeadService creates team backend led by u1.
+func newLeadService(t *testing.T) *service.Service {
+	t.Helper()
+
+	svc := newMemoryService(t)
+	_, err := svc.AddTeamAddUpdateUsers(adminContext(), &model.Team{
+		Name: "backend",
+		Members: []model.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+		Leads: []string{"u1"},
+	})
+	if err != nil {
+		t.Fatalf("AddTeamAddUpdateUsers: %v", err)
+	}
+	return svc
+}
+
+func requireForbidden(t *testing.T, err error) {
+	t.Helper()
+
+	var forbidden errs.ForbiddenError
+	if !errors.As(err, &forbidden) {
+		t.Fatalf("want ForbiddenError, got %v", err)
+	}
+}
+
+func TestSetUserActivityUnknownUser(t *testing.T) {
+	svc := newLeadService(t)
+	lead := service.WithIdentity(context.Background(), service.Identity{UserID: "u1"})
+	admin := adminContext()
+
+	_, _, err := svc.SetUserActivity(lead, "missing", false, false)
+	requireForbidden(t, err)
+
+	_, _, err = svc.SetUserActivity(admin, "missing", false, false)
+	if !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("want NotFoundErr for admin, got %v", err)
+	}
+
+	user, _, err := svc.SetUserActivity(lead, "u2", false, false)
+	if err != nil {
+		t.Fatalf("SetUserActivity: %v", err)
+	}
+	if user.IsActive {
+		t.Fatalf("user is still active: %+v", user)
+	}
+}
+
+func TestAddTeamMembersUnknownTeam(t *testing.T) {
+	svc := newLeadService(t)
+	lead := service.WithIdentity(context.Background(), service.Identity{UserID: "u1"})
+	admin := adminContext()
+	members := []model.TeamMember{{UserID: "u3", Username: "Carol", IsActive: true}}
+
+	_, err := svc.AddTeamMembers(lead, "missing", members)
+	requireForbidden(t, err)
+
+	_, err = svc.AddTeamMembers(admin, "missing", members)
+	if !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("want NotFoundErr for admin, got %v", err)
+	}
+}
+
+func TestMissingIdentity(t *testing.T) {
+	svc := newLeadService(t)
+	ctx := context.Background()
+
+	_, err := svc.AddTeamAddUpdateUsers(ctx, &model.Team{Name: "frontend"})
+	requireForbidden(t, err)
+	_, err = svc.GetTeam(ctx, "backend")
+	requireForbidden(t, err)
+	_, err = svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr1", Name: "Add login", AuthorID: "u1"})
+	requireForbidden(t, err)
+	_, _, err = svc.SetUserActivity(ctx, "u2", false, false)
+	requireForbidden(t, err)
+	_, err = svc.GetUserAssignments(ctx, "u2")
+	requireForbidden(t, err)
+	_, err = svc.GetStats(ctx)
+	requireForbidden(t, err)
+}
+
+func TestSystemIdentity(t *testing.T) {
+	svc := newLeadService(t)
+	ctx := service.WithSystemIdentity(context.Background(), "webhook:github")
+
+	if _, err := svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr1", Name: "Add login", AuthorID: "u1"}); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	events, err := svc.GetPullRequestHistory(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequestHistory: %v", err)
+	}
+	if len(events) == 0 || events[0].Actor != "webhook:github" {
+		t.Fatalf("want events of webhook:github, got %+v", events)
+	}
+}
+
+func TestGetTeamMember(t *testing.T) {
+	svc := newLeadService(t)
+	addTeam(t, svc, "frontend", 1, "f1")
+	member := service.WithIdentity(context.Background(), service.Identity{UserID: "u2"})
+	outsider := service.WithIdentity(context.Background(), service.Identity{UserID: "f1"})
+
+	team, err := svc.GetTeam(member, "backend")
+	if err != nil {
+		t.Fatalf("GetTeam: %v", err)
+	}
+	if team.Name != "backend" {
+		t.Fatalf("unexpected team %+v", team)
+	}
+
+	_, err = svc.GetTeam(outsider, "backend")
+	requireForbidden(t, err)
+	_, err = svc.GetTeam(member, "missing")
+	requireForbidden(t, err)
+
+	_, err = svc.GetTeam(adminContext(), "missing")
+	if !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("want NotFoundErr for admin, got %v", err)
+	}
+}
+
+func TestReassignPullRequestUnknownPullRequest(t *testing.T) {
+	svc := newLeadService(t)
+	addTeam(t, svc, "frontend", 1, "f1", "f2")
+	lead := service.WithIdentity(context.Background(), service.Identity{UserID: "u1"})
+
+	if _, err := svc.CreatePullRequest(adminContext(), &model.PullRequestShort{Id: "pr1", Name: "Fix layout", AuthorID: "f1"}); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	// pull request of other team and missing one are indistinguishable
+	_, _, errOther := svc.ReassignPullRequest(lead, "pr1", "f2")
+	requireForbidden(t, errOther)
+	_, _, errMissing := svc.ReassignPullRequest(lead, "missing", "f2")
+	requireForbidden(t, errMissing)
+	if strings.ReplaceAll(errOther.Error(), "pr1", "missing") != errMissing.Error() {
+		t.Fatalf("errors differ: %q and %q", errOther, errMissing)
+	}
+
+	_, _, err := svc.ReassignPullRequest(adminContext(), "missing", "f2")
+	if !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("want NotFoundErr for admin, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// replaceReviewer replaces oldReviewerID on open pull request with reviewer from author's team.
+// If author's team has no candidates and fallbacks map it to another team, reviewer is picked from that team.
+// Users in exclude are not picked, e.g. reviewers replaced earlier.
+// pr is updated in place and the change is recorded in history with given reason.
+// Exactly one reviewer is picked, missing reviewers are added by backfill.
+// errs.NoCandidateErr is returned if there is no one to replace reviewer with,
+// errs.NotAssignedErr if oldReviewerID doesn't review pr.
+func (s *Service) replaceReviewer(ctx context.Context, pr *model.PullRequest, oldReviewerID string, fallbacks map[string]string, reason string, exclude []string) (model.Reassignment, error) {
+	i := slices.Index(pr.AssignedReviewers, oldReviewerID)
+	if i == -1 {
+		return model.Reassignment{}, errs.NotAssignedErr
+	}
+
+	// Search by author id and not oldReviewerID because reviewer could've changed team,
+	// and we need original team to review pr.
+	team, err := s.getAuthorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return model.Reassignment{}, err
+	}
+
+	candidates, err := s.getCandidates(ctx, pr)
+	if err != nil {
+		return model.Reassignment{}, err
+	}
+	candidates = excludeCandidates(candidates, exclude)
+
+	selectorTeam := team.Name
+	fallbackTeam, hasFallback := fallbacks[team.Name]
+	if len(candidates) < 1 && hasFallback {
+		candidates, err = s.getTeamCandidates(ctx, fallbackTeam, pr)
+		if err != nil {
+			return model.Reassignment{}, err
+		}
+		candidates = excludeCandidates(candidates, exclude)
+		selectorTeam = fallbackTeam
+	} else {
+		fallbackTeam = ""
+	}
+
+	if len(candidates) < 1 {
+		return model.Reassignment{}, errs.NoCandidateErr
+	}
+
+	picked, err := s.selectors.forTeam(selectorTeam).Select(ctx, candidates, 1)
+	if err != nil {
+		return model.Reassignment{}, fmt.Errorf("failed to select reviewer: %w", err)
+	}
+	if len(picked) < 1 {
+		return model.Reassignment{}, errs.NoCandidateErr
+	}
+
+	if err := s.storage.DeleteReviewAssignment(ctx, pr.Id, oldReviewerID); err != nil {
+		return model.Reassignment{}, fmt.Errorf("storage failed to delete review assignment: %w", err)
+	}
+
+	newReviewerID, err := s.storage.AddReviewAssignment(ctx, pr.Id, picked[0])
+	if err != nil {
+		return model.Reassignment{}, fmt.Errorf("storage failed to add review assignment: %w", err)
+	}
+	pr.AssignedReviewers[i] = newReviewerID
+
+	err = s.recordEvents(ctx, model.AssignmentEvent{
+		PullRequestID: pr.Id,
+		Type:          model.AssignmentEventREASSIGNED,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+		Reason:        reason,
+	})
+	if err != nil {
+		return model.Reassignment{}, err
+	}
+
+	if err := s.updateNeedMoreReviewers(ctx, pr, team.RequiredReviewers); err != nil {
+		return model.Reassignment{}, err
+	}
+
+	return model.Reassignment{
+		PullRequestID: pr.Id,
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+		FallbackTeam:  fallbackTeam,
+	}, nil
+}
+
+// excludeCandidates removes users in exclude from candidates.
+func excludeCandidates(candidates, exclude []string) []string {
+	return slices.DeleteFunc(candidates, func(id string) bool {
+		return slices.Contains(exclude, id)
+	})
+}
+
+// getTeamCandidates returns active members of team who are neither author of pull request nor assigned to it.
+func (s *Service) getTeamCandidates(ctx context.Context, teamName string, pr *model.PullRequest) ([]string, error) {
+	team, err := s.storage.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get team %s: %w", teamName, err)
+	}
+
+	candidates := make([]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		if member.IsActive && member.UserID != pr.AuthorID && !slices.Contains(pr.AssignedReviewers, member.UserID) {
+			candidates = append(candidates, member.UserID)
+		}
+	}
+
+	return candidates, nil
+}
+
+// removeReviewer unassigns reviewer from pull request without replacement.
+// pr is updated in place and marked as needing more reviewers if necessary.
+func (s *Service) removeReviewer(ctx context.Context, pr *model.PullRequest, reviewerID, reason string) error {
+	team, err := s.getAuthorTeam(ctx, pr.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.DeleteReviewAssignment(ctx, pr.Id, reviewerID); err != nil {
+		return fmt.Errorf("storage failed to delete review assignment: %w", err)
+	}
+	pr.AssignedReviewers = slices.DeleteFunc(pr.AssignedReviewers, func(id string) bool {
+		return id == reviewerID
+	})
+
+	err = s.recordEvents(ctx, model.AssignmentEvent{
+		PullRequestID: pr.Id,
+		Type:          model.AssignmentEventUNASSIGNED,
+		OldReviewerID: reviewerID,
+		Reason:        reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.updateNeedMoreReviewers(ctx, pr, team.RequiredReviewers)
+}
+
+// updateNeedMoreReviewers stores pr.NeedMoreReviewers if it changed.
+func (s *Service) updateNeedMoreReviewers(ctx context.Context, pr *model.PullRequest, requiredReviewers int) error {
+	needMore := len(pr.AssignedReviewers) < requiredReviewers
+	if needMore == pr.NeedMoreReviewers {
+		return nil
+	}
+
+	pr.NeedMoreReviewers = needMore
+	updatedPR, err := s.storage.UpdatePullRequest(ctx, pr)
+	if err != nil {
+		return fmt.Errorf("storage failed to update pull request: %w", err)
+	}
+	*pr = *updatedPR
+
+	return nil
+}
+
+// reassignOpenReviews moves open review assignments of reviewerID to other active members of authors' teams.
+// Pull requests without replacement candidate lose the reviewer and are marked as needing more reviewers.
+// See replaceReviewer for fallbacks. Must be called in transaction.
+func (s *Service) reassignOpenReviews(ctx context.Context, reviewerID string, fallbacks map[string]string, reason string, report *model.ReassignmentReport) error {
+	assignments, err := s.storage.GetUserAssignments(ctx, reviewerID)
+	if err != nil {
+		return fmt.Errorf("storage failed to get user assignments: %w", err)
+	}
+
+	for _, assignment := range assignments {
+		if assignment.Status != model.PullRequestStatusOPEN {
+			continue
+		}
+
+		pr, err := s.storage.GetPullRequest(ctx, assignment.Id)
+		if err != nil {
+			return fmt.Errorf("storage failed to get pull request: %w", err)
+		}
+
+		reassignment, err := s.replaceReviewer(ctx, pr, reviewerID, fallbacks, reason, nil)
+		if errors.Is(err, errs.NoCandidateErr) {
+			if err := s.removeReviewer(ctx, pr, reviewerID, reason); err != nil {
+				return err
+			}
+			report.NoCandidate = append(report.NoCandidate, model.Reassignment{
+				PullRequestID: pr.Id,
+				OldReviewerID: reviewerID,
+			})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		report.Reassigned = append(report.Reassigned, reassignment)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/storage"
+)
+
+// Names of built-in selection strategies used in config.SelectionConfig.
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round-robin"
+	StrategyWeighted    = "weighted"
+	StrategyLeastLoaded = "least-loaded"
+)
+
+// ReviewerSelector picks reviewers for a pull request among candidates.
+type ReviewerSelector interface {
+	// Select returns at most n distinct reviewers from candidates.
+	// Implementations must not modify candidates.
+	Select(ctx context.Context, candidates []string, n int) ([]string, error)
+}
+
+// RandomSelector picks reviewers uniformly at random.
+type RandomSelector struct{}
+
+func (RandomSelector) Select(_ context.Context, candidates []string, n int) ([]string, error) {
+	if len(candidates) <= n {
+		return slices.Clone(candidates), nil
+	}
+
+	shuffled := slices.Clone(candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled[:n], nil
+}
+
+// RoundRobinSelector takes turns over members of a team, so every member is picked before anyone is picked twice.
+// Members take turns in order of their ids. A member who can't review a pull request, e.g. its author,
+// keeps its turn for the next one.
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	turn int
+	// lastTurn holds turn at which member was picked last, members who were never picked go first.
+	lastTurn map[string]int
+}
+
+func (s *RoundRobinSelector) Select(_ context.Context, candidates []string, n int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastTurn == nil {
+		s.lastTurn = make(map[string]int)
+	}
+
+	// candidates come from storage in no particular order
+	sorted := slices.Clone(candidates)
+	slices.SortFunc(sorted, func(a, b string) int {
+		return cmp.Or(cmp.Compare(s.lastTurn[a], s.lastTurn[b]), cmp.Compare(a, b))
+	})
+	reviewers := sorted[:min(n, len(sorted))]
+
+	s.turn++
+	for _, reviewer := range reviewers {
+		s.lastTurn[reviewer] = s.turn
+	}
+
+	return reviewers, nil
+}
+
+// WeightedSelector picks reviewers at random with probability proportional to their weight.
+// Candidates without configured weight have weight of 1.
+type WeightedSelector struct {
+	weights map[string]float64
+}
+
+func NewWeightedSelector(weights map[string]float64) *WeightedSelector {
+	return &WeightedSelector{weights: weights}
+}
+
+func (s *WeightedSelector) Select(_ context.Context, candidates []string, n int) ([]string, error) {
+	if len(candidates) <= n {
+		return slices.Clone(candidates), nil
+	}
+
+	remaining := slices.Clone(candidates)
+	reviewers := make([]string, 0, n)
+	for len(reviewers) < n {
+		i := s.pick(remaining)
+		reviewers = append(reviewers, remaining[i])
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+
+	return reviewers, nil
+}
+
+// pick returns index of weighted random candidate.
+// If all candidates have zero weight, candidate is picked uniformly.
+func (s *WeightedSelector) pick(candidates []string) int {
+	var total float64
+	for _, c := range candidates {
+		total += s.weight(c)
+	}
+	if total <= 0 {
+		return rand.IntN(len(candidates))
+	}
+
+	r := rand.Float64() * total
+	for i, c := range candidates {
+		r -= s.weight(c)
+		if r < 0 {
+			return i
+		}
+	}
+	return len(candidates) - 1
+}
+
+func (s *WeightedSelector) weight(userID string) float64 {
+	w, ok := s.weights[userID]
+	if !ok {
+		return 1
+	}
+	return max(w, 0)
+}
+
+// LeastLoadedSelector picks reviewers with the fewest open review assignments.
+// Ties are broken randomly.
+type LeastLoadedSelector struct {
+	storage storage.ReviewAssignment
+}
+
+func NewLeastLoadedSelector(storage storage.ReviewAssignment) *LeastLoadedSelector {
+	return &LeastLoadedSelector{storage: storage}
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, candidates []string, n int) ([]string, error) {
+	if len(candidates) <= n {
+		return slices.Clone(candidates), nil
+	}
+
+	loads, err := s.storage.GetOpenAssignmentCounts(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get open assignment counts: %w", err)
+	}
+
+	// shuffle before stable sort so candidates with equal load are ordered randomly
+	sorted := slices.Clone(candidates)
+	rand.Shuffle(len(sorted), func(i, j int) {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	})
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		return loads[a] - loads[b]
+	})
+
+	return sorted[:n], nil
+}
+
+// selectors resolves ReviewerSelector for a team according to config.
+// Selectors are created lazily and kept per team, so stateful strategies
+// like round-robin don't interfere between teams.
+type selectors struct {
+	cfg     config.SelectionConfig
+	storage storage.ReviewAssignment
+
+	mu     sync.Mutex
+	byTeam map[string]ReviewerSelector
+}
+
+func newSelectors(cfg config.SelectionConfig, storage storage.ReviewAssignment) (*selectors, error) {
+	s := &selectors{cfg: cfg, storage: storage, byTeam: make(map[string]ReviewerSelector)}
+
+	if _, err := s.newSelector(cfg.Strategy); err != nil {
+		return nil, err
+	}
+	for team, strategy := range cfg.TeamStrategies {
+		if _, err := s.newSelector(strategy); err != nil {
+			return nil, fmt.Errorf("team %s: %w", team, err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *selectors) forTeam(teamName string) ReviewerSelector {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if selector, ok := s.byTeam[teamName]; ok {
+		return selector
+	}
+
+	strategy, ok := s.cfg.TeamStrategies[teamName]
+	if !ok {
+		strategy = s.cfg.Strategy
+	}
+
+	// strategies are validated in newSelectors
+	selector, _ := s.newSelector(strategy)
+	s.byTeam[teamName] = selector
+
+	return selector
+}
+
+func (s *selectors) newSelector(strategy string) (ReviewerSelector, error) {
+	switch strategy {
+	case StrategyRandom:
+		return RandomSelector{}, nil
+	case StrategyRoundRobin:
+		return &RoundRobinSelector{}, nil
+	case StrategyWeighted:
+		return NewWeightedSelector(s.cfg.ReviewerWeights), nil
+	case StrategyLeastLoaded:
+		return NewLeastLoadedSelector(s.storage), nil
+	default:
+		return nil, fmt.Errorf("unknown selection strategy %q", strategy)
+	}
+}
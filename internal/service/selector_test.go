@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"review-assigner/internal/service"
+	"review-assigner/internal/storage"
+)
+
+// requireSelected checks that selector picked n distinct reviewers among candidates and kept candidates intact.
+func requireSelected(t *testing.T, selector service.ReviewerSelector, candidates []string, n int) []string {
+	t.Helper()
+
+	original := slices.Clone(candidates)
+	reviewers, err := selector.Select(context.Background(), candidates, n)
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if !slices.Equal(candidates, original) {
+		t.Fatalf("candidates modified: %v, was %v", candidates, original)
+	}
+	if want := min(n, len(candidates)); len(reviewers) != want {
+		t.Fatalf("want %d reviewers, got %v", want, reviewers)
+	}
+	for i, reviewer := range reviewers {
+		if !slices.Contains(candidates, reviewer) || slices.Contains(reviewers[:i], reviewer) {
+			t.Fatalf("reviewers %v are not distinct candidates of %v", reviewers, candidates)
+		}
+	}
+	return reviewers
+}
+
+func TestRandomSelector(t *testing.T) {
+	candidates := []string{"u1", "u2", "u3", "u4"}
+
+	for _, n := range []int{0, 1, 2, 4, 5} {
+		requireSelected(t, service.RandomSelector{}, candidates, n)
+	}
+
+	// every candidate is eventually picked
+	picked := map[string]bool{}
+	for range 200 {
+		for _, reviewer := range requireSelected(t, service.RandomSelector{}, candidates, 1) {
+			picked[reviewer] = true
+		}
+	}
+	if len(picked) != len(candidates) {
+		t.Fatalf("want every candidate picked, got %v", picked)
+	}
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	selector := &service.RoundRobinSelector{}
+
+	steps := []struct {
+		candidates []string
+		want       []string
+	}{
+		// u1 authored pull request, so it keeps its turn
+		{candidates: []string{"u4", "u3", "u2"}, want: []string{"u2"}},
+		{candidates: []string{"u3", "u1", "u4", "u2"}, want: []string{"u1"}},
+		{candidates: []string{"u1", "u2", "u3", "u4"}, want: []string{"u3", "u4"}},
+		// u2 was picked before u1
+		{candidates: []string{"u1", "u2", "u3", "u4"}, want: []string{"u2", "u1"}},
+		// not enough candidates, all are picked
+		{candidates: []string{"u4", "u3"}, want: []string{"u3", "u4"}},
+		// u1 and u2 were picked at the same turn, ties are broken by id
+		{candidates: []string{"u1", "u2", "u3", "u4"}, want: []string{"u1"}},
+	}
+
+	for i, step := range steps {
+		got := requireSelected(t, selector, step.candidates, len(step.want))
+		if !slices.Equal(got, step.want) {
+			t.Fatalf("step %d: want %v, got %v", i, step.want, got)
+		}
+	}
+}
+
+func TestRoundRobinSelectorFair(t *testing.T) {
+	selector := &service.RoundRobinSelector{}
+	members := []string{"u1", "u2", "u3", "u4", "u5"}
+
+	// authors take turns, so candidates differ between calls
+	counts := map[string]int{}
+	for i := range 100 {
+		author := members[i%len(members)]
+		candidates := slices.DeleteFunc(slices.Clone(members), func(id string) bool { return id == author })
+		for _, reviewer := range requireSelected(t, selector, candidates, 2) {
+			counts[reviewer]++
+		}
+	}
+
+	// 200 reviews are split between 5 members
+	for _, member := range members {
+		if counts[member] < 39 || counts[member] > 41 {
+			t.Fatalf("want 40±1 reviews of each member, got %v", counts)
+		}
+	}
+}
+
+func TestWeightedSelector(t *testing.T) {
+	selector := service.NewWeightedSelector(map[string]float64{"u1": 0, "u2": 3})
+	candidates := []string{"u1", "u2", "u3"}
+
+	counts := map[string]int{}
+	for range 1000 {
+		for _, reviewer := range requireSelected(t, selector, candidates, 1) {
+			counts[reviewer]++
+		}
+	}
+	if counts["u1"] != 0 {
+		t.Fatalf("candidate with zero weight picked: %v", counts)
+	}
+	// u2 has weight 3 and u3 has default weight 1
+	if counts["u2"] < 2*counts["u3"] {
+		t.Fatalf("want u2 picked about 3 times as often as u3, got %v", counts)
+	}
+
+	// candidate with zero weight is picked if there is no one else
+	reviewers := requireSelected(t, selector, candidates, 3)
+	if !slices.Contains(reviewers, "u1") {
+		t.Fatalf("want u1 among %v", reviewers)
+	}
+}
+
+func TestWeightedSelectorZeroWeights(t *testing.T) {
+	selector := service.NewWeightedSelector(map[string]float64{"u1": 0, "u2": 0, "u3": 0})
+
+	for range 100 {
+		requireSelected(t, selector, []string{"u1", "u2", "u3"}, 2)
+	}
+}
+
+// loadStub reports fixed open assignment counts.
+type loadStub struct {
+	storage.ReviewAssignment
+	loads map[string]int
+}
+
+func (s loadStub) GetOpenAssignmentCounts(_ context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		counts[id] = s.loads[id]
+	}
+	return counts, nil
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	selector := service.NewLeastLoadedSelector(loadStub{loads: map[string]int{"u1": 3, "u2": 1, "u3": 0, "u4": 1}})
+	candidates := []string{"u1", "u2", "u3", "u4"}
+
+	reviewers := requireSelected(t, selector, candidates, 1)
+	if !slices.Equal(reviewers, []string{"u3"}) {
+		t.Fatalf("want least loaded u3, got %v", reviewers)
+	}
+
+	// u2 and u4 have equal load, both are picked sometimes
+	picked := map[string]bool{}
+	for range 100 {
+		reviewers := requireSelected(t, selector, candidates, 2)
+		if reviewers[0] != "u3" || reviewers[1] == "u1" {
+			t.Fatalf("want u3 and one of u2, u4, got %v", reviewers)
+		}
+		picked[reviewers[1]] = true
+	}
+	if !picked["u2"] || !picked["u4"] {
+		t.Fatalf("want ties broken randomly, picked %v", picked)
+	}
+}
@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand/v2"
+	"log/slog"
 	"slices"
 	"time"
 
+	"review-assigner/internal/config"
 	"review-assigner/internal/errs"
 	"review-assigner/internal/model"
 	"review-assigner/internal/storage"
@@ -18,20 +20,44 @@ import (
 // This service is fairly simple so one object is enough,
 // but in the future it could be separated into more services.
 type Service struct {
-	storage storage.Storage
+	storage       storage.Storage
+	selectors     *selectors
+	fallbackTeams map[string]string
+	// outboxSinks receive notifications about review assignments through outbox
+	outboxSinks []string
 }
 
-func NewService(storage storage.Storage) *Service {
-	return &Service{
-		storage: storage,
+// NewService creates service. Notifications are added to outbox for each of outboxSinks,
+// see outbox.Dispatcher.
+func NewService(storage storage.Storage, selectionCfg config.SelectionConfig, outboxSinks []string) (*Service, error) {
+	selectors, err := newSelectors(selectionCfg, storage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selection config: %w", err)
 	}
+
+	return &Service{
+		storage:       storage,
+		selectors:     selectors,
+		fallbackTeams: selectionCfg.FallbackTeams,
+		outboxSinks:   outboxSinks,
+	}, nil
 }
 
+// AddTeamAddUpdateUsers creates team with members. Leads of team, if given, must be its members.
 func (s *Service) AddTeamAddUpdateUsers(ctx context.Context, team *model.Team) (*model.Team, error) {
+	if err := authorizeAdmin(ctx, "add teams"); err != nil {
+		return nil, err
+	}
+
 	var result *model.Team
 
 	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
-		teamName, err := s.storage.AddTeam(ctx, team.Name)
+		requiredReviewers := team.RequiredReviewers
+		if requiredReviewers == 0 {
+			requiredReviewers = model.DefaultRequiredReviewers
+		}
+
+		addedTeam, err := s.storage.AddTeam(ctx, team.Name, requiredReviewers)
 		if err != nil {
 			return fmt.Errorf("storage failed to add team: %w", err)
 		}
@@ -39,10 +65,12 @@ func (s *Service) AddTeamAddUpdateUsers(ctx context.Context, team *model.Team) (
 		inputUsers := make([]model.User, len(team.Members))
 		for i, member := range team.Members {
 			inputUsers[i] = model.User{
-				Id:       member.UserID,
-				Username: member.Username,
-				TeamName: team.Name,
-				IsActive: member.IsActive,
+				Id:         member.UserID,
+				Username:   member.Username,
+				TeamName:   team.Name,
+				IsActive:   member.IsActive,
+				ChatHandle: member.ChatHandle,
+				Email:      member.Email,
 			}
 		}
 
@@ -58,17 +86,33 @@ func (s *Service) AddTeamAddUpdateUsers(ctx context.Context, team *model.Team) (
 		members := make([]model.TeamMember, len(users))
 		for i, user := range users {
 			members[i] = model.TeamMember{
-				UserID:   user.Id,
-				Username: user.Username,
-				IsActive: user.IsActive,
+				UserID:     user.Id,
+				Username:   user.Username,
+				IsActive:   user.IsActive,
+				ChatHandle: user.ChatHandle,
+				Email:      user.Email,
+			}
+		}
+
+		addedTeam.Members = members
+
+		if len(team.Leads) > 0 {
+			if err := s.setTeamLeads(ctx, addedTeam, team.Leads); err != nil {
+				return err
 			}
 		}
 
-		result = &model.Team{
-			Name:    teamName,
-			Members: members,
+		if team.ReviewSLASeconds > 0 || team.ReviewSLAAutoReassign {
+			err := s.storage.SetTeamReviewSLA(ctx, team.Name, team.ReviewSLASeconds, team.ReviewSLAAutoReassign)
+			if err != nil {
+				return fmt.Errorf("storage failed to set team review sla: %w", err)
+			}
+			addedTeam.ReviewSLASeconds = team.ReviewSLASeconds
+			addedTeam.ReviewSLAAutoReassign = team.ReviewSLAAutoReassign
 		}
 
+		result = addedTeam
+
 		return nil
 	})
 
@@ -78,45 +122,288 @@ func (s *Service) AddTeamAddUpdateUsers(ctx context.Context, team *model.Team) (
 	return result, nil
 }
 
+// GetTeam returns team with its members and leads.
 func (s *Service) GetTeam(ctx context.Context, name string) (*model.Team, error) {
-	team, err := s.storage.GetTeam(ctx, name)
+	var result *model.Team
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		// authorize first, so users can't probe which teams exist
+		if err := s.authorizeTeamMember(ctx, name); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = s.getTeamWithLeads(ctx, name)
+		return err
+	})
+
 	if err != nil {
-		return nil, fmt.Errorf("storage failed to get team: %w", err)
+		return nil, err
 	}
-	return team, nil
+	return result, nil
 }
 
-func (s *Service) SetUserActivity(ctx context.Context, id string, active bool) (*model.User, error) {
-	user, err := s.storage.SetUserActivity(ctx, id, active)
+// SetTeamLeads replaces leads of team. Leads must be members of team.
+func (s *Service) SetTeamLeads(ctx context.Context, name string, leads []string) (*model.Team, error) {
+	if err := authorizeAdmin(ctx, "set team leads"); err != nil {
+		return nil, err
+	}
+
+	var result *model.Team
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		team, err := s.storage.GetTeam(ctx, name)
+		if err != nil {
+			return fmt.Errorf("storage failed to get team: %w", err)
+		}
+
+		if err := s.setTeamLeads(ctx, team, leads); err != nil {
+			return err
+		}
+
+		result = team
+		return nil
+	})
+
 	if err != nil {
-		return nil, fmt.Errorf("storage failed to set user activity: %w", err)
+		return nil, err
 	}
-	return user, nil
+	return result, nil
+}
+
+// AddTeamMembers adds new members to existing team and updates existing ones.
+// Team leads can't move users from other teams, only admins can.
+// Open reviews of members who are deactivated by update are moved to other active members,
+// as SetUserActivity does.
+func (s *Service) AddTeamMembers(ctx context.Context, teamName string, members []model.TeamMember) (*model.Team, error) {
+	var result *model.Team
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		// authorize first, so team leads can't probe which teams exist
+		if err := s.authorizeTeamLead(ctx, teamName); err != nil {
+			return err
+		}
+		if _, err := s.storage.GetTeam(ctx, teamName); err != nil {
+			return fmt.Errorf("storage failed to get team: %w", err)
+		}
+
+		identity, _ := IdentityFromContext(ctx)
+		users := make([]model.User, len(members))
+		var deactivated []string
+		for i, member := range members {
+			existing, err := s.storage.GetUser(ctx, member.UserID)
+			if err != nil && !errors.Is(err, errs.NotFoundErr) {
+				return fmt.Errorf("storage failed to get user: %w", err)
+			}
+			if existing != nil {
+				if !identity.Admin && existing.TeamName != teamName {
+					return errs.ForbiddenError{UserID: identity.UserID, Action: "move users from team " + existing.TeamName}
+				}
+				if existing.IsActive && !member.IsActive {
+					deactivated = append(deactivated, member.UserID)
+				}
+			}
+
+			users[i] = model.User{
+				Id:         member.UserID,
+				Username:   member.Username,
+				TeamName:   teamName,
+				IsActive:   member.IsActive,
+				ChatHandle: member.ChatHandle,
+				Email:      member.Email,
+			}
+		}
+
+		if _, err := s.storage.AddUpdateUsers(ctx, users); err != nil {
+			return fmt.Errorf("storage failed to add/update users: %w", err)
+		}
+
+		report := model.NewReassignmentReport()
+		for _, id := range deactivated {
+			if err := s.reassignOpenReviews(ctx, id, nil, model.ReasonUserDeactivated, report); err != nil {
+				return err
+			}
+		}
+		if len(report.Reassigned) > 0 || len(report.NoCandidate) > 0 {
+			slog.Info("reassigned reviews of deactivated members", "team_name", teamName,
+				"reassigned", len(report.Reassigned), "no_candidate", len(report.NoCandidate))
+		}
+
+		// new active members can review pull requests that lack reviewers
+		if _, err := s.BackfillReviewers(ctx, teamName); err != nil {
+			return fmt.Errorf("failed to backfill reviewers: %w", err)
+		}
+
+		var err error
+		result, err = s.getTeamWithLeads(ctx, teamName)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetTeamRequiredReviewers updates number of reviewers assigned to pull requests of team members.
+// Open pull requests of team which now lack reviewers are backfilled, see BackfillReviewers.
+func (s *Service) SetTeamRequiredReviewers(ctx context.Context, name string, requiredReviewers int) (*model.Team, error) {
+	if err := authorizeAdmin(ctx, "set required reviewers"); err != nil {
+		return nil, err
+	}
+
+	var result *model.Team
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		if err := s.storage.SetTeamRequiredReviewers(ctx, name, requiredReviewers); err != nil {
+			return fmt.Errorf("storage failed to set team required reviewers: %w", err)
+		}
+
+		if err := s.storage.UpdateNeedMoreReviewers(ctx, name, requiredReviewers); err != nil {
+			return fmt.Errorf("storage failed to update need more reviewers: %w", err)
+		}
+		backfilled, err := s.BackfillReviewers(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to backfill reviewers: %w", err)
+		}
+		if len(backfilled) > 0 {
+			slog.Info("backfilled reviewers on required reviewers change", "team_name", name, "pull_requests", len(backfilled))
+		}
+
+		result, err = s.storage.GetTeam(ctx, name)
+		if err != nil {
+			return fmt.Errorf("storage failed to get team: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetUserActivity also backfills reviewers of understaffed pull requests in user's team
+// when user becomes active.
+//
+// If user is deactivated and reassignReviews is set, open reviews of user are moved to other
+// active members of authors' teams, and report of moved reviews is returned.
+// Otherwise, report is nil.
+func (s *Service) SetUserActivity(ctx context.Context, id string, active, reassignReviews bool) (*model.User, *model.ReassignmentReport, error) {
+	var (
+		result *model.User
+		report *model.ReassignmentReport
+	)
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.storage.GetUser(ctx, id)
+		if errors.Is(err, errs.NotFoundErr) {
+			// only admins learn that user doesn't exist, others can't manage unknown user anyway
+			if err := authorizeAdmin(ctx, "set activity of user "+id); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("storage failed to get user: %w", err)
+		}
+		if err := s.authorizeTeamLead(ctx, user.TeamName); err != nil {
+			return err
+		}
+
+		result, err = s.storage.SetUserActivity(ctx, id, active)
+		if err != nil {
+			return fmt.Errorf("storage failed to set user activity: %w", err)
+		}
+
+		if !active {
+			if !reassignReviews {
+				return nil
+			}
+			report = model.NewReassignmentReport()
+			return s.reassignOpenReviews(ctx, id, nil, model.ReasonUserDeactivated, report)
+		}
+
+		backfilled, err := s.BackfillReviewers(ctx, result.TeamName)
+		if err != nil {
+			return fmt.Errorf("failed to backfill reviewers: %w", err)
+		}
+		if len(backfilled) > 0 {
+			slog.Info("backfilled reviewers on user activation", "user_id", id, "pull_requests", len(backfilled))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, report, nil
+}
+
+// DeactivateTeam deactivates all members of team and moves their open reviews
+// to active members of authors' teams.
+// Reviews of pull requests authored by the team itself are moved to its fallback team, if configured.
+func (s *Service) DeactivateTeam(ctx context.Context, teamName string) (*model.ReassignmentReport, error) {
+	if err := authorizeAdmin(ctx, "deactivate teams"); err != nil {
+		return nil, err
+	}
+
+	report := model.NewReassignmentReport()
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.storage.GetTeam(ctx, teamName); err != nil {
+			return fmt.Errorf("storage failed to get team: %w", err)
+		}
+
+		// deactivate everyone first, so members of team are not picked as replacements
+		users, err := s.storage.SetTeamActivity(ctx, teamName, false)
+		if err != nil {
+			return fmt.Errorf("storage failed to set team activity: %w", err)
+		}
+
+		var fallbacks map[string]string
+		if fallback, ok := s.fallbackTeams[teamName]; ok {
+			fallbacks = map[string]string{teamName: fallback}
+		}
+
+		for _, user := range users {
+			if err := s.reassignOpenReviews(ctx, user.Id, fallbacks, model.ReasonTeamDeactivated, report); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
 }
 
 // CreatePullRequest ignores status field in model.PullRequestShort
 func (s *Service) CreatePullRequest(ctx context.Context, pr *model.PullRequestShort) (*model.PullRequest, error) {
+	if err := authorizeAdmin(ctx, "create pull requests"); err != nil {
+		return nil, err
+	}
+
 	var result *model.PullRequest
 
 	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		team, err := s.getAuthorTeam(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
 		activeColleges, err := s.storage.GetActiveColleges(ctx, pr.AuthorID)
 		if err != nil {
 			return fmt.Errorf("storage failed to get active collegs: %w", err)
 		}
 
-		// Pick two distinct reviewers at random
-		reviewers := make([]string, 0, 2)
-		if len(activeColleges) <= 2 {
-			reviewers = activeColleges
-		} else {
-			i1 := rand.Int() % len(activeColleges)
-			reviewers = append(reviewers, activeColleges[i1])
-			i2 := rand.Int() % (len(activeColleges) - 1)
-			// increment to avoid picking same college twice
-			if i2 >= i1 {
-				i2++
-			}
-			reviewers = append(reviewers, activeColleges[i2])
+		reviewers, err := s.selectors.forTeam(team.Name).Select(ctx, activeColleges, team.RequiredReviewers)
+		if err != nil {
+			return fmt.Errorf("failed to select reviewers: %w", err)
 		}
 
 		createdAt := time.Now()
@@ -128,6 +415,7 @@ func (s *Service) CreatePullRequest(ctx context.Context, pr *model.PullRequestSh
 			AssignedReviewers: reviewers,
 			CreatedAt:         &createdAt,
 			MergedAt:          nil,
+			NeedMoreReviewers: len(reviewers) < team.RequiredReviewers,
 		}
 
 		result, err = s.storage.CreatePullRequestWithAssignments(ctx, inputPR)
@@ -135,6 +423,19 @@ func (s *Service) CreatePullRequest(ctx context.Context, pr *model.PullRequestSh
 			return fmt.Errorf("storage failed to create pull request with assignments: %w", err)
 		}
 
+		events := make([]model.AssignmentEvent, len(result.AssignedReviewers))
+		for i, reviewerID := range result.AssignedReviewers {
+			events[i] = model.AssignmentEvent{
+				PullRequestID: result.Id,
+				Type:          model.AssignmentEventASSIGNED,
+				NewReviewerID: reviewerID,
+				Reason:        model.ReasonPullRequestCreated,
+			}
+		}
+		if err := s.recordEvents(ctx, events...); err != nil {
+			return err
+		}
+
 		return nil
 	})
 
@@ -145,6 +446,10 @@ func (s *Service) CreatePullRequest(ctx context.Context, pr *model.PullRequestSh
 }
 
 func (s *Service) MergePullRequest(ctx context.Context, id string) (*model.PullRequest, error) {
+	if err := authorizeAdmin(ctx, "merge pull requests"); err != nil {
+		return nil, err
+	}
+
 	var result *model.PullRequest
 
 	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
@@ -153,6 +458,12 @@ func (s *Service) MergePullRequest(ctx context.Context, id string) (*model.PullR
 			return fmt.Errorf("storage failed to get pull request: %w", err)
 		}
 
+		// merge is idempotent
+		if pr.Status == model.PullRequestStatusMERGED {
+			result = pr
+			return nil
+		}
+
 		pr.Status = model.PullRequestStatusMERGED
 		mergedAt := time.Now()
 		pr.MergedAt = &mergedAt
@@ -162,7 +473,12 @@ func (s *Service) MergePullRequest(ctx context.Context, id string) (*model.PullR
 			return fmt.Errorf("storage failed to update pull request: %w", err)
 		}
 
-		return nil
+		return s.recordEvents(ctx, model.AssignmentEvent{
+			PullRequestID: result.Id,
+			Type:          model.AssignmentEventMERGED,
+			Reason:        model.ReasonPullRequestMerged,
+			CreatedAt:     mergedAt,
+		})
 	})
 
 	if err != nil {
@@ -173,9 +489,10 @@ func (s *Service) MergePullRequest(ctx context.Context, id string) (*model.PullR
 
 func (s *Service) ReassignPullRequest(ctx context.Context, pullRequestID, oldReviewerID string) (pr *model.PullRequest, newReviewerID string, err error) {
 	err = s.storage.InTransaction(ctx, func(ctx context.Context) error {
-		pr, err := s.storage.GetPullRequest(ctx, pullRequestID)
+		var err error
+		pr, err = s.getPullRequestAsLead(ctx, pullRequestID, "reassign reviewers of pull request "+pullRequestID)
 		if err != nil {
-			return fmt.Errorf("storage failed to get pull request: %w", err)
+			return err
 		}
 
 		if pr.Status == model.PullRequestStatusMERGED {
@@ -186,48 +503,111 @@ func (s *Service) ReassignPullRequest(ctx context.Context, pullRequestID, oldRev
 			return errs.NotAssignedErr
 		}
 
-		// Search by author id and not oldReviewerID because reviewer could've changed team,
-		// and we need original team to review pr.
-		activeColleges, err := s.storage.GetActiveColleges(ctx, pr.AuthorID)
+		reassignment, err := s.replaceReviewer(ctx, pr, oldReviewerID, nil, model.ReasonManualReassign, nil)
 		if err != nil {
-			return fmt.Errorf("storage failed to get active colleges: %w", err)
+			return err
 		}
+		newReviewerID = reassignment.NewReviewerID
 
-		// remove already assigned reviewers
-		for _, reviewer := range pr.AssignedReviewers {
-			if i := slices.Index(activeColleges, reviewer); i != -1 {
-				activeColleges = slices.Delete(activeColleges, i, i+1)
-			}
-		}
+		return nil
+	})
 
-		if len(activeColleges) < 1 {
-			return errs.NoCandidateErr
-		}
+	if err != nil {
+		return nil, "", err
+	}
+	return pr, newReviewerID, nil
+}
 
-		newReviewerID = activeColleges[rand.Int()%len(activeColleges)]
+// GetUnderstaffedPullRequests returns open pull requests that need more reviewers.
+// Empty teamName matches all teams.
+func (s *Service) GetUnderstaffedPullRequests(ctx context.Context, teamName string) ([]model.PullRequest, error) {
+	if err := authorizeAdmin(ctx, "read understaffed pull requests"); err != nil {
+		return nil, err
+	}
 
-		if err := s.storage.DeleteReviewAssignment(ctx, pullRequestID, oldReviewerID); err != nil {
-			return fmt.Errorf("stoage failed to delete review assignment: %w", err)
-		}
+	prs, err := s.storage.GetUnderstaffedPullRequests(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get understaffed pull requests: %w", err)
+	}
+	return prs, nil
+}
 
-		insertedNewReviewerID, err := s.storage.AddReviewAssignment(ctx, pullRequestID, newReviewerID)
+// BackfillReviewers assigns missing reviewers to open pull requests of team members
+// that need more reviewers. It returns pull requests that got new reviewers.
+func (s *Service) BackfillReviewers(ctx context.Context, teamName string) ([]model.PullRequest, error) {
+	var result []model.PullRequest
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		prs, err := s.storage.GetUnderstaffedPullRequests(ctx, teamName)
 		if err != nil {
-			return fmt.Errorf("storage failed to add review assignment: %w", err)
+			return fmt.Errorf("storage failed to get understaffed pull requests: %w", err)
 		}
 
-		i := slices.Index(pr.AssignedReviewers, oldReviewerID)
-		pr.AssignedReviewers[i] = insertedNewReviewerID
+		for _, pr := range prs {
+			// author could've changed team since pull request was filtered
+			team, err := s.getAuthorTeam(ctx, pr.AuthorID)
+			if err != nil {
+				return err
+			}
+
+			candidates, err := s.getCandidates(ctx, &pr)
+			if err != nil {
+				return err
+			}
+
+			missing := team.RequiredReviewers - len(pr.AssignedReviewers)
+			picked, err := s.selectors.forTeam(team.Name).Select(ctx, candidates, max(missing, 0))
+			if err != nil {
+				return fmt.Errorf("failed to select reviewers: %w", err)
+			}
+
+			events := make([]model.AssignmentEvent, len(picked))
+			for i, reviewerID := range picked {
+				insertedReviewerID, err := s.storage.AddReviewAssignment(ctx, pr.Id, reviewerID)
+				if err != nil {
+					return fmt.Errorf("storage failed to add review assignment: %w", err)
+				}
+				pr.AssignedReviewers = append(pr.AssignedReviewers, insertedReviewerID)
+				events[i] = model.AssignmentEvent{
+					PullRequestID: pr.Id,
+					Type:          model.AssignmentEventASSIGNED,
+					NewReviewerID: insertedReviewerID,
+					Reason:        model.ReasonBackfill,
+				}
+			}
+			if err := s.recordEvents(ctx, events...); err != nil {
+				return err
+			}
+
+			pr.NeedMoreReviewers = len(pr.AssignedReviewers) < team.RequiredReviewers
+			if len(picked) == 0 && pr.NeedMoreReviewers {
+				continue
+			}
+
+			updatedPR, err := s.storage.UpdatePullRequest(ctx, &pr)
+			if err != nil {
+				return fmt.Errorf("storage failed to update pull request: %w", err)
+			}
+			if len(picked) > 0 {
+				result = append(result, *updatedPR)
+			}
+		}
 
 		return nil
 	})
 
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
-	return pr, newReviewerID, nil
+	return result, nil
 }
 
+// GetUserAssignments is allowed to admins, user itself and leads of user's team.
 func (s *Service) GetUserAssignments(ctx context.Context, id string) ([]model.PullRequestShort, error) {
+	if err := s.authorizeUser(ctx, id, "read reviews of "+id); err != nil {
+		return nil, err
+	}
+
 	shortPRs, err := s.storage.GetUserAssignments(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("storage failed to get user assignments: %w", err)
@@ -235,3 +615,66 @@ func (s *Service) GetUserAssignments(ctx context.Context, id string) ([]model.Pu
 
 	return shortPRs, nil
 }
+
+// getAuthorTeam returns team pull requests of author are reviewed by.
+func (s *Service) getAuthorTeam(ctx context.Context, authorID string) (*model.Team, error) {
+	author, err := s.storage.GetUser(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get author: %w", err)
+	}
+
+	team, err := s.storage.GetTeam(ctx, author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get author team: %w", err)
+	}
+
+	return team, nil
+}
+
+// getTeamWithLeads returns team with its members and leads.
+func (s *Service) getTeamWithLeads(ctx context.Context, name string) (*model.Team, error) {
+	team, err := s.storage.GetTeam(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get team: %w", err)
+	}
+
+	team.Leads, err = s.storage.GetTeamLeads(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get team leads: %w", err)
+	}
+
+	return team, nil
+}
+
+// setTeamLeads replaces leads of team after checking they are its members, and sets team.Leads.
+func (s *Service) setTeamLeads(ctx context.Context, team *model.Team, leads []string) error {
+	for _, id := range leads {
+		if !slices.ContainsFunc(team.Members, func(m model.TeamMember) bool { return m.UserID == id }) {
+			return fmt.Errorf("%w: lead %s is not a member of team %s", errs.NotFoundErr, id, team.Name)
+		}
+	}
+
+	if err := s.storage.SetTeamLeads(ctx, team.Name, leads); err != nil {
+		return fmt.Errorf("storage failed to set team leads: %w", err)
+	}
+
+	var err error
+	team.Leads, err = s.storage.GetTeamLeads(ctx, team.Name)
+	if err != nil {
+		return fmt.Errorf("storage failed to get team leads: %w", err)
+	}
+
+	return nil
+}
+
+// getCandidates returns active colleges of pull request author who are not yet assigned to it.
+func (s *Service) getCandidates(ctx context.Context, pr *model.PullRequest) ([]string, error) {
+	activeColleges, err := s.storage.GetActiveColleges(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get active colleges: %w", err)
+	}
+
+	return slices.DeleteFunc(activeColleges, func(id string) bool {
+		return slices.Contains(pr.AssignedReviewers, id)
+	}), nil
+}
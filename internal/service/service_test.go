@@ -0,0 +1,272 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/service"
+	"review-assigner/internal/storage/memory"
+	"review-assigner/internal/storage/postgres/pgtest"
+)
+
+func newService(t *testing.T) *service.Service {
+	t.Helper()
+
+	svc, err := service.NewService(pgtest.New(t), config.SelectionConfig{Strategy: "random"}, nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc
+}
+
+// newMemoryService creates service backed by memory storage for tests which don't depend on storage.
+func newMemoryService(t *testing.T) *service.Service {
+	t.Helper()
+
+	svc, err := service.NewService(memory.New(), config.SelectionConfig{Strategy: "random"}, nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc
+}
+
+// adminContext returns context of admin caller.
+func adminContext() context.Context {
+	return service.WithIdentity(context.Background(), service.Identity{Admin: true})
+}
+
+func addTeam(t *testing.T, svc *service.Service, name string, requiredReviewers int, userIDs ...string) {
+	t.Helper()
+
+	team := &model.Team{Name: name, RequiredReviewers: requiredReviewers}
+	for _, id := range userIDs {
+		team.Members = append(team.Members, model.TeamMember{UserID: id, Username: "user " + id, IsActive: true})
+	}
+	if _, err := svc.AddTeamAddUpdateUsers(adminContext(), team); err != nil {
+		t.Fatalf("AddTeamAddUpdateUsers: %v", err)
+	}
+}
+
+func TestReassignPullRequest(t *testing.T) {
+	svc := newService(t)
+	ctx := adminContext()
+	addTeam(t, svc, "backend", 2, "u1", "u2", "u3", "u4")
+
+	created, err := svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr1", Name: "Add login", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if len(created.AssignedReviewers) != 2 {
+		t.Fatalf("want 2 reviewers, got %v", created.AssignedReviewers)
+	}
+	oldReviewerID := created.AssignedReviewers[0]
+	keptReviewerID := created.AssignedReviewers[1]
+
+	pr, newReviewerID, err := svc.ReassignPullRequest(ctx, "pr1", oldReviewerID)
+	if err != nil {
+		t.Fatalf("ReassignPullRequest: %v", err)
+	}
+	if newReviewerID == "" || newReviewerID == oldReviewerID || newReviewerID == keptReviewerID || newReviewerID == "u1" {
+		t.Fatalf("unexpected new reviewer %q replacing %q", newReviewerID, oldReviewerID)
+	}
+	// new reviewer takes place of the old one
+	if want := []string{newReviewerID, keptReviewerID}; !slices.Equal(pr.AssignedReviewers, want) {
+		t.Fatalf("want reviewers %v, got %v", want, pr.AssignedReviewers)
+	}
+	if pr.NeedMoreReviewers {
+		t.Fatal("want pull request fully staffed")
+	}
+
+	history, err := svc.GetPullRequestHistory(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequestHistory: %v", err)
+	}
+	last := history[len(history)-1]
+	if last.Type != model.AssignmentEventREASSIGNED || last.OldReviewerID != oldReviewerID || last.NewReviewerID != newReviewerID {
+		t.Fatalf("unexpected last event %+v", last)
+	}
+
+	if _, _, err := svc.ReassignPullRequest(ctx, "pr1", oldReviewerID); !errors.Is(err, errs.NotAssignedErr) {
+		t.Fatalf("want NotAssignedErr, got %v", err)
+	}
+	// replaced reviewer is the only member who doesn't review pull request now
+	if _, newReviewerID, err = svc.ReassignPullRequest(ctx, "pr1", keptReviewerID); err != nil {
+		t.Fatalf("ReassignPullRequest: %v", err)
+	}
+	if newReviewerID != oldReviewerID {
+		t.Fatalf("want %q to replace %q, got %q", oldReviewerID, keptReviewerID, newReviewerID)
+	}
+	if _, _, err := svc.ReassignPullRequest(ctx, "missing", oldReviewerID); !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("want NotFoundErr, got %v", err)
+	}
+
+	if _, err := svc.MergePullRequest(ctx, "pr1"); err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+	if _, _, err := svc.ReassignPullRequest(ctx, "pr1", newReviewerID); !errors.Is(err, errs.PullRequestMergedErr) {
+		t.Fatalf("want PullRequestMergedErr, got %v", err)
+	}
+}
+
+func TestReassignPullRequestNoCandidate(t *testing.T) {
+	svc := newService(t)
+	ctx := adminContext()
+	addTeam(t, svc, "backend", 2, "u1", "u2", "u3")
+
+	created, err := svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr1", Name: "Add login", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	if _, _, err := svc.ReassignPullRequest(ctx, "pr1", created.AssignedReviewers[0]); !errors.Is(err, errs.NoCandidateErr) {
+		t.Fatalf("want NoCandidateErr, got %v", err)
+	}
+	// failed reassignment is rolled back
+	history, err := svc.GetPullRequestHistory(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequestHistory: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("want 2 events, got %+v", history)
+	}
+}
+
+func TestReassignPullRequestReplacesOne(t *testing.T) {
+	st := memory.New()
+	svc, err := service.NewService(st, config.SelectionConfig{Strategy: "random"}, nil)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	ctx := adminContext()
+	addTeam(t, svc, "backend", 3, "u1", "u2", "u3", "u4", "u5")
+
+	created, err := svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr1", Name: "Add login", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	// leave pull request understaffed, as if one reviewer was removed without replacement
+	if err := st.DeleteReviewAssignment(ctx, "pr1", created.AssignedReviewers[2]); err != nil {
+		t.Fatalf("DeleteReviewAssignment: %v", err)
+	}
+
+	oldReviewerID := created.AssignedReviewers[0]
+	pr, newReviewerID, err := svc.ReassignPullRequest(ctx, "pr1", oldReviewerID)
+	if err != nil {
+		t.Fatalf("ReassignPullRequest: %v", err)
+	}
+	if want := []string{newReviewerID, created.AssignedReviewers[1]}; !slices.Equal(pr.AssignedReviewers, want) {
+		t.Fatalf("want only %q replaced: %v, got %v", oldReviewerID, want, pr.AssignedReviewers)
+	}
+
+	history, err := svc.GetPullRequestHistory(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequestHistory: %v", err)
+	}
+	if last := history[len(history)-1]; last.Type != model.AssignmentEventREASSIGNED || last.NewReviewerID != newReviewerID {
+		t.Fatalf("want reassignment to be the last event, got %+v", history)
+	}
+}
+
+func TestAddTeamMembersDeactivates(t *testing.T) {
+	svc := newMemoryService(t)
+	ctx := adminContext()
+	addTeam(t, svc, "backend", 2, "u1", "u2", "u3", "u4")
+
+	created, err := svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr1", Name: "Add login", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	oldReviewerID := created.AssignedReviewers[0]
+
+	team, err := svc.AddTeamMembers(ctx, "backend", []model.TeamMember{
+		{UserID: oldReviewerID, Username: "user " + oldReviewerID, IsActive: false},
+	})
+	if err != nil {
+		t.Fatalf("AddTeamMembers: %v", err)
+	}
+	for _, member := range team.Members {
+		if member.UserID == oldReviewerID && member.IsActive {
+			t.Fatalf("member %s is still active", oldReviewerID)
+		}
+	}
+
+	history, err := svc.GetPullRequestHistory(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequestHistory: %v", err)
+	}
+	last := history[len(history)-1]
+	if last.Type != model.AssignmentEventREASSIGNED || last.OldReviewerID != oldReviewerID ||
+		last.Reason != model.ReasonUserDeactivated {
+		t.Fatalf("unexpected last event %+v", last)
+	}
+
+	reviews, err := svc.GetUserAssignments(ctx, oldReviewerID)
+	if err != nil {
+		t.Fatalf("GetUserAssignments: %v", err)
+	}
+	if len(reviews) != 0 {
+		t.Fatalf("deactivated member still reviews %+v", reviews)
+	}
+	reviews, err = svc.GetUserAssignments(ctx, last.NewReviewerID)
+	if err != nil {
+		t.Fatalf("GetUserAssignments: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].Id != "pr1" {
+		t.Fatalf("want new reviewer %s to review pr1, got %+v", last.NewReviewerID, reviews)
+	}
+}
+
+func TestSetTeamRequiredReviewersBackfills(t *testing.T) {
+	svc := newMemoryService(t)
+	ctx := adminContext()
+	addTeam(t, svc, "backend", 1, "u1", "u2", "u3", "u4")
+
+	created, err := svc.CreatePullRequest(ctx, &model.PullRequestShort{Id: "pr1", Name: "Add login", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if len(created.AssignedReviewers) != 1 {
+		t.Fatalf("want 1 reviewer, got %v", created.AssignedReviewers)
+	}
+
+	team, err := svc.SetTeamRequiredReviewers(ctx, "backend", 3)
+	if err != nil {
+		t.Fatalf("SetTeamRequiredReviewers: %v", err)
+	}
+	if team.RequiredReviewers != 3 {
+		t.Fatalf("want 3 required reviewers, got %d", team.RequiredReviewers)
+	}
+
+	history, err := svc.GetPullRequestHistory(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequestHistory: %v", err)
+	}
+	reviewers := []string{created.AssignedReviewers[0]}
+	for _, event := range history {
+		if event.Type == model.AssignmentEventASSIGNED && event.Reason == model.ReasonBackfill {
+			reviewers = append(reviewers, event.NewReviewerID)
+		}
+	}
+	slices.Sort(reviewers)
+	if !slices.Equal(reviewers, []string{"u2", "u3", "u4"}) {
+		t.Fatalf("want reviewers u2, u3 and u4 after backfill, got %v", reviewers)
+	}
+
+	understaffed, err := svc.GetUnderstaffedPullRequests(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetUnderstaffedPullRequests: %v", err)
+	}
+	if len(understaffed) != 0 {
+		t.Fatalf("want no understaffed pull requests, got %+v", understaffed)
+	}
+
+	_, err = svc.SetTeamRequiredReviewers(ctx, "missing", 2)
+	if !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("want NotFoundErr, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"review-assigner/internal/model"
+)
+
+// GetStats collects review load statistics per user and per team.
+func (s *Service) GetStats(ctx context.Context) (*model.Stats, error) {
+	if err := authorizeAdmin(ctx, "read stats"); err != nil {
+		return nil, err
+	}
+
+	var result model.Stats
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		result.Users, err = s.storage.GetUserStats(ctx)
+		if err != nil {
+			return fmt.Errorf("storage failed to get user stats: %w", err)
+		}
+
+		result.Teams, err = s.storage.GetTeamStats(ctx)
+		if err != nil {
+			return fmt.Errorf("storage failed to get team stats: %w", err)
+		}
+
+		result.ReassignmentsByReason, err = s.storage.GetReassignmentCounts(ctx)
+		if err != nil {
+			return fmt.Errorf("storage failed to get reassignment counts: %w", err)
+		}
+		for _, count := range result.ReassignmentsByReason {
+			result.Reassignments += count
+		}
+
+		result.AverageTimeToMergeSeconds, err = s.storage.GetAverageTimeToMerge(ctx)
+		if err != nil {
+			return fmt.Errorf("storage failed to get average time to merge: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"review-assigner/internal/model"
+)
+
+// userTokenBytes is number of random bytes in user api token.
+const userTokenBytes = 32
+
+// IssueUserToken creates new api token of user.
+// Token is returned only here, storage keeps its hash.
+func (s *Service) IssueUserToken(ctx context.Context, userID, name string) (*model.IssuedUserToken, error) {
+	if err := authorizeAdmin(ctx, "manage user tokens"); err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, userTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	created, err := s.storage.AddUserToken(ctx, &model.UserToken{
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}, hashUserToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to add user token: %w", err)
+	}
+
+	return &model.IssuedUserToken{UserToken: *created, Token: token}, nil
+}
+
+// GetUserTokens lists tokens of user including revoked ones.
+func (s *Service) GetUserTokens(ctx context.Context, userID string) ([]model.UserToken, error) {
+	if err := authorizeAdmin(ctx, "manage user tokens"); err != nil {
+		return nil, err
+	}
+
+	var result []model.UserToken
+
+	err := s.storage.InTransaction(ctx, func(ctx context.Context) error {
+		// distinguish missing user from user without tokens
+		if _, err := s.storage.GetUser(ctx, userID); err != nil {
+			return fmt.Errorf("storage failed to get user: %w", err)
+		}
+
+		var err error
+		result, err = s.storage.GetUserTokens(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("storage failed to get user tokens: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RevokeUserToken revokes token so it can't be used anymore. Revoking revoked token changes nothing.
+func (s *Service) RevokeUserToken(ctx context.Context, id int64) (*model.UserToken, error) {
+	if err := authorizeAdmin(ctx, "manage user tokens"); err != nil {
+		return nil, err
+	}
+
+	token, err := s.storage.RevokeUserToken(ctx, id, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to revoke user token: %w", err)
+	}
+	return token, nil
+}
+
+// AuthenticateUserToken returns owner of not revoked token.
+func (s *Service) AuthenticateUserToken(ctx context.Context, token string) (*model.User, error) {
+	user, err := s.storage.GetUserByTokenHash(ctx, hashUserToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("storage failed to get user by token: %w", err)
+	}
+	return user, nil
+}
+
+// hashUserToken hashes token for storing. Tokens are random and long enough,
+// so plain SHA-256 is sufficient unlike for passwords.
+func hashUserToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
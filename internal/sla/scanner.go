@@ -0,0 +1,59 @@
+// Package sla escalates pull requests which wait for review longer than review SLA of author's team.
+package sla
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/service"
+)
+
+// Actor is recorded in assignment events made by scanner.
+const Actor = "sla"
+
+// Scanner periodically escalates stale pull requests, see service.EscalateStalePullRequests.
+type Scanner struct {
+	service *service.Service
+	cfg     config.SLAConfig
+}
+
+func NewScanner(service *service.Service, cfg config.SLAConfig) *Scanner {
+	return &Scanner{service: service, cfg: cfg}
+}
+
+// Run scans pull requests every scan interval until ctx is done.
+func (s *Scanner) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.ScanInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Scan(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("failed to escalate stale pull requests", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan escalates pull requests which exceeded review SLA by now.
+// Escalations are logged even if some pull requests failed to escalate.
+func (s *Scanner) Scan(ctx context.Context) error {
+	escalations, err := s.service.EscalateStalePullRequests(service.WithSystemIdentity(ctx, Actor), time.Now())
+	for _, escalation := range escalations {
+		slog.Info("escalated stale pull request",
+			"pull_request_id", escalation.PullRequestID,
+			"reassigned", len(escalation.Reassigned),
+			"no_candidate", len(escalation.NoCandidate))
+	}
+	if err != nil {
+		return fmt.Errorf("service failed to escalate stale pull requests: %w", err)
+	}
+	return nil
+}
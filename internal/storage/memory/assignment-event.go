@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"review-assigner/internal/model"
+)
+
+// AddAssignmentEvents appends events to review assignment history.
+func (s *Storage) AddAssignmentEvents(ctx context.Context, events []model.AssignmentEvent) error {
+	defer s.lock(ctx)()
+
+	for _, e := range events {
+		if _, ok := s.data.pullRequests[e.PullRequestID]; !ok {
+			return fmt.Errorf("memory failed to add assignment event: pull request %s does not exist", e.PullRequestID)
+		}
+	}
+
+	for _, e := range events {
+		e.ID = int64(len(s.data.events) + 1)
+		s.data.events = append(s.data.events, e)
+	}
+
+	return nil
+}
+
+// GetAssignmentEvents retrieves review assignment history of pull request.
+func (s *Storage) GetAssignmentEvents(ctx context.Context, prID string) ([]model.AssignmentEvent, error) {
+	defer s.lock(ctx)()
+
+	events := make([]model.AssignmentEvent, 0)
+	for _, e := range s.data.events {
+		if e.PullRequestID == prID {
+			events = append(events, e)
+		}
+	}
+
+	return events, nil
+}
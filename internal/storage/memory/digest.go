@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// SetDigestOptOut unsubscribes user from digests or subscribes back.
+func (s *Storage) SetDigestOptOut(ctx context.Context, userID string, optOut bool) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.users[userID]; !ok {
+		return errs.NotFoundErr
+	}
+	digest := s.data.digests[userID]
+	digest.optOut = optOut
+	s.data.digests[userID] = digest
+
+	return nil
+}
+
+// GetDigestRecipients retrieves active users with email who haven't opted out of digests, ordered by id.
+func (s *Storage) GetDigestRecipients(ctx context.Context) ([]model.User, error) {
+	defer s.lock(ctx)()
+
+	recipients := make([]model.User, 0)
+	for _, user := range s.data.sortedUsers() {
+		if user.IsActive && user.Email != "" && !s.data.digests[user.Id].optOut {
+			recipients = append(recipients, user)
+		}
+	}
+
+	return recipients, nil
+}
+
+// ClaimDigest sets time of last digest of user unless digest was already sent not before since.
+func (s *Storage) ClaimDigest(ctx context.Context, userID string, sentAt, since time.Time) (bool, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.users[userID]; !ok {
+		return false, errs.NotFoundErr
+	}
+	digest := s.data.digests[userID]
+	if digest.lastSentAt != nil && !digest.lastSentAt.Before(since) {
+		return false, nil
+	}
+	digest.lastSentAt = &sentAt
+	s.data.digests[userID] = digest
+
+	return true, nil
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// GetStalePullRequestIDs retrieves ids of open pull requests which exceeded review SLA of author's team
+// and are not escalated yet, oldest first.
+func (s *Storage) GetStalePullRequestIDs(ctx context.Context, now time.Time) ([]string, error) {
+	defer s.lock(ctx)()
+
+	ids := make([]string, 0)
+	for _, id := range s.data.sortedPullRequestIDs() {
+		pr := s.data.pullRequests[id]
+		if pr.Status != model.PullRequestStatusOPEN || pr.CreatedAt == nil {
+			continue
+		}
+		if _, ok := s.data.escalations[id]; ok {
+			continue
+		}
+		team := s.data.teams[s.data.users[pr.AuthorID].TeamName]
+		if team.ReviewSLASeconds <= 0 {
+			continue
+		}
+		if pr.CreatedAt.Add(time.Duration(team.ReviewSLASeconds) * time.Second).After(now) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// AddPullRequestEscalation records escalation of pull request unless it is already escalated.
+func (s *Storage) AddPullRequestEscalation(ctx context.Context, prID string, escalatedAt time.Time) (bool, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.pullRequests[prID]; !ok {
+		return false, errs.NotFoundErr
+	}
+	if _, ok := s.data.escalations[prID]; ok {
+		return false, nil
+	}
+	s.data.escalations[prID] = escalatedAt
+
+	return true, nil
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// SetExternalIdentity maps login to user, replacing previous mapping of login.
+func (s *Storage) SetExternalIdentity(ctx context.Context, identity model.ExternalIdentity) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.users[identity.UserID]; !ok {
+		return errs.NotFoundErr
+	}
+	s.data.identities[externalLogin{provider: identity.Provider, login: identity.Login}] = identity.UserID
+
+	return nil
+}
+
+// GetExternalIdentities retrieves identities of user.
+func (s *Storage) GetExternalIdentities(ctx context.Context, userID string) ([]model.ExternalIdentity, error) {
+	defer s.lock(ctx)()
+
+	identities := make([]model.ExternalIdentity, 0)
+	for key, id := range s.data.identities {
+		if id == userID {
+			identities = append(identities, model.ExternalIdentity{Provider: key.provider, Login: key.login, UserID: id})
+		}
+	}
+	slices.SortFunc(identities, func(a, b model.ExternalIdentity) int {
+		return cmp.Or(cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.Login, b.Login))
+	})
+
+	return identities, nil
+}
+
+// DeleteExternalIdentity removes mapping of login.
+func (s *Storage) DeleteExternalIdentity(ctx context.Context, provider, login string) error {
+	defer s.lock(ctx)()
+
+	key := externalLogin{provider: provider, login: login}
+	if _, ok := s.data.identities[key]; !ok {
+		return errs.NotFoundErr
+	}
+	delete(s.data.identities, key)
+
+	return nil
+}
+
+// GetUserByExternalIdentity finds user mapped to login.
+func (s *Storage) GetUserByExternalIdentity(ctx context.Context, provider, login string) (*model.User, error) {
+	defer s.lock(ctx)()
+
+	id, ok := s.data.identities[externalLogin{provider: provider, login: login}]
+	if !ok {
+		return nil, errs.NotFoundErr
+	}
+	user, ok := s.data.users[id]
+	if !ok {
+		return nil, errs.NotFoundErr
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"review-assigner/internal/storage"
+	"review-assigner/internal/storage/memory"
+	"review-assigner/internal/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return memory.New()
+	})
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// AddOutboxMessages adds messages to outbox.
+func (s *Storage) AddOutboxMessages(ctx context.Context, messages []model.OutboxMessage) error {
+	defer s.lock(ctx)()
+
+	for _, m := range messages {
+		m.ID = int64(len(s.data.outbox) + 1)
+		m.Payload = slices.Clone(m.Payload)
+		m.ProcessedAt = cloneTime(m.ProcessedAt)
+		s.data.outbox = append(s.data.outbox, m)
+	}
+
+	return nil
+}
+
+// ClaimOutboxMessages leases due messages.
+func (s *Storage) ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxMessage, error) {
+	defer s.lock(ctx)()
+
+	var due []int
+	for i, m := range s.data.outbox {
+		if m.Status == model.OutboxMessagePENDING && !m.NextAttemptAt.After(now) {
+			due = append(due, i)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b int) int {
+		return s.data.outbox[a].NextAttemptAt.Compare(s.data.outbox[b].NextAttemptAt)
+	})
+	due = due[:min(limit, len(due))]
+
+	claimed := make([]model.OutboxMessage, 0, len(due))
+	for _, i := range due {
+		s.data.outbox[i].NextAttemptAt = now.Add(lease)
+
+		m := s.data.outbox[i]
+		m.Payload = slices.Clone(m.Payload)
+		m.ProcessedAt = cloneTime(m.ProcessedAt)
+		claimed = append(claimed, m)
+	}
+	slices.SortFunc(claimed, func(a, b model.OutboxMessage) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return claimed, nil
+}
+
+// UpdateOutboxMessage saves outcome of processing attempt.
+func (s *Storage) UpdateOutboxMessage(ctx context.Context, message *model.OutboxMessage) error {
+	defer s.lock(ctx)()
+
+	for i, m := range s.data.outbox {
+		if m.ID != message.ID {
+			continue
+		}
+		m.Status = message.Status
+		m.Attempts = message.Attempts
+		m.NextAttemptAt = message.NextAttemptAt
+		m.LastError = message.LastError
+		m.ProcessedAt = cloneTime(message.ProcessedAt)
+		s.data.outbox[i] = m
+		return nil
+	}
+
+	return errs.NotFoundErr
+}
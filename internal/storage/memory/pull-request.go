@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// CreatePullRequestWithAssignments creates pull request and assigns users to it.
+func (s *Storage) CreatePullRequestWithAssignments(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.pullRequests[pr.Id]; ok {
+		return nil, errs.PullRequestExistsError{PullRequestID: pr.Id}
+	}
+	if _, ok := s.data.users[pr.AuthorID]; !ok {
+		return nil, fmt.Errorf("memory failed to create pull request %s: author %s does not exist", pr.Id, pr.AuthorID)
+	}
+	for i, reviewer := range pr.AssignedReviewers {
+		if _, ok := s.data.users[reviewer]; !ok {
+			return nil, fmt.Errorf("memory failed to create pull request %s: reviewer %s does not exist", pr.Id, reviewer)
+		}
+		if slices.Contains(pr.AssignedReviewers[:i], reviewer) {
+			return nil, fmt.Errorf("memory failed to create pull request %s: reviewer %s is duplicated", pr.Id, reviewer)
+		}
+	}
+
+	stored := *pr
+	stored.AssignedReviewers = nil
+	stored.CreatedAt = cloneTime(pr.CreatedAt)
+	stored.MergedAt = cloneTime(pr.MergedAt)
+	s.data.pullRequests[pr.Id] = stored
+	s.data.assignments[pr.Id] = slices.Clone(pr.AssignedReviewers)
+
+	return s.data.pullRequest(pr.Id), nil
+}
+
+func (s *Storage) GetPullRequest(ctx context.Context, id string) (*model.PullRequest, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.pullRequests[id]; !ok {
+		return nil, errs.NotFoundErr
+	}
+
+	return s.data.pullRequest(id), nil
+}
+
+func (s *Storage) UpdatePullRequest(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.pullRequests[pr.Id]; !ok {
+		return nil, errs.NotFoundErr
+	}
+	if _, ok := s.data.users[pr.AuthorID]; !ok {
+		return nil, fmt.Errorf("memory failed to update pull request %s: author %s does not exist", pr.Id, pr.AuthorID)
+	}
+
+	stored := *pr
+	stored.AssignedReviewers = nil
+	stored.CreatedAt = cloneTime(pr.CreatedAt)
+	stored.MergedAt = cloneTime(pr.MergedAt)
+	s.data.pullRequests[pr.Id] = stored
+
+	// like postgres storage, reviewers are taken from argument and not from storage
+	updated := stored
+	updated.AssignedReviewers = pr.AssignedReviewers
+	return &updated, nil
+}
+
+// GetUnderstaffedPullRequests finds open pull requests marked as needing more reviewers, oldest first.
+func (s *Storage) GetUnderstaffedPullRequests(ctx context.Context, teamName string) ([]model.PullRequest, error) {
+	defer s.lock(ctx)()
+
+	result := make([]model.PullRequest, 0)
+	for _, id := range s.data.sortedPullRequestIDs() {
+		pr := s.data.pullRequests[id]
+		if pr.Status != model.PullRequestStatusOPEN || !pr.NeedMoreReviewers {
+			continue
+		}
+		if teamName != "" && s.data.users[pr.AuthorID].TeamName != teamName {
+			continue
+		}
+		result = append(result, *s.data.pullRequest(id))
+	}
+
+	return result, nil
+}
+
+// UpdateNeedMoreReviewers recomputes need for more reviewers of open pull requests of team authors.
+func (s *Storage) UpdateNeedMoreReviewers(ctx context.Context, teamName string, requiredReviewers int) error {
+	defer s.lock(ctx)()
+
+	for id, pr := range s.data.pullRequests {
+		if pr.Status != model.PullRequestStatusOPEN || s.data.users[pr.AuthorID].TeamName != teamName {
+			continue
+		}
+		pr.NeedMoreReviewers = len(s.data.assignments[id]) < requiredReviewers
+		s.data.pullRequests[id] = pr
+	}
+
+	return nil
+}
+
+// pullRequest returns copy of stored pull request with its reviewers.
+func (d data) pullRequest(id string) *model.PullRequest {
+	pr := d.pullRequests[id]
+	pr.CreatedAt = cloneTime(pr.CreatedAt)
+	pr.MergedAt = cloneTime(pr.MergedAt)
+	pr.AssignedReviewers = slices.Clone(d.assignments[id])
+	if pr.AssignedReviewers == nil {
+		pr.AssignedReviewers = []string{}
+	}
+	return &pr
+}
+
+// sortedPullRequestIDs returns ids of pull requests ordered by creation time.
+func (d data) sortedPullRequestIDs() []string {
+	ids := make([]string, 0, len(d.pullRequests))
+	for id := range d.pullRequests {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b string) int {
+		ca, cb := d.pullRequests[a].CreatedAt, d.pullRequests[b].CreatedAt
+		if ca != nil && cb != nil && !ca.Equal(*cb) {
+			return ca.Compare(*cb)
+		}
+		return strings.Compare(a, b)
+	})
+	return ids
+}
@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+func (s *Storage) DeleteReviewAssignment(ctx context.Context, prID string, userID string) error {
+	defer s.lock(ctx)()
+
+	if reviewers, ok := s.data.assignments[prID]; ok {
+		s.data.assignments[prID] = slices.DeleteFunc(reviewers, func(id string) bool {
+			return id == userID
+		})
+	}
+
+	return nil
+}
+
+// AddReviewAssignment assigns user to pull request as a reviewer.
+func (s *Storage) AddReviewAssignment(ctx context.Context, prID string, userID string) (reviewerID string, err error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.pullRequests[prID]; !ok {
+		return "", errs.NotFoundErr
+	}
+	if _, ok := s.data.users[userID]; !ok {
+		return "", errs.NotFoundErr
+	}
+	if slices.Contains(s.data.assignments[prID], userID) {
+		return "", errs.ReviewAssignmentExistsError{PullRequestID: prID, UserID: userID}
+	}
+
+	s.data.assignments[prID] = append(s.data.assignments[prID], userID)
+
+	return userID, nil
+}
+
+func (s *Storage) GetUserAssignments(ctx context.Context, userID string) ([]model.PullRequestShort, error) {
+	defer s.lock(ctx)()
+
+	prs := make([]model.PullRequestShort, 0)
+	for _, id := range s.data.sortedPullRequestIDs() {
+		if !slices.Contains(s.data.assignments[id], userID) {
+			continue
+		}
+		pr := s.data.pullRequests[id]
+		prs = append(prs, model.PullRequestShort{
+			Id:       pr.Id,
+			Name:     pr.Name,
+			AuthorID: pr.AuthorID,
+			Status:   pr.Status,
+		})
+	}
+
+	return prs, nil
+}
+
+// GetOpenAssignmentCounts counts review assignments on not merged pull requests for each of userIDs.
+func (s *Storage) GetOpenAssignmentCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	defer s.lock(ctx)()
+
+	counts := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		counts[id] = 0
+	}
+
+	for prID, reviewers := range s.data.assignments {
+		if s.data.pullRequests[prID].Status == model.PullRequestStatusMERGED {
+			continue
+		}
+		for _, reviewer := range reviewers {
+			if _, ok := counts[reviewer]; ok {
+				counts[reviewer]++
+			}
+		}
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"review-assigner/internal/model"
+)
+
+// GetUserStats counts current review assignments of every user.
+func (s *Storage) GetUserStats(ctx context.Context) ([]model.UserStats, error) {
+	defer s.lock(ctx)()
+
+	users := s.data.sortedUsers()
+	stats := make([]model.UserStats, len(users))
+	for i, user := range users {
+		stats[i] = model.UserStats{
+			UserID:   user.Id,
+			Username: user.Username,
+			TeamName: user.TeamName,
+		}
+		stats[i].TotalAssignments, stats[i].OpenAssignments, stats[i].MergedAssignments = s.data.countAssignments(func(reviewer string) bool {
+			return reviewer == user.Id
+		})
+	}
+
+	for _, e := range s.data.events {
+		if e.Type != model.AssignmentEventREASSIGNED {
+			continue
+		}
+		i := slices.IndexFunc(stats, func(stat model.UserStats) bool { return stat.UserID == e.OldReviewerID })
+		if i != -1 {
+			stats[i].ReassignedFrom++
+		}
+	}
+
+	return stats, nil
+}
+
+// GetTeamStats counts current review assignments of members of every team.
+func (s *Storage) GetTeamStats(ctx context.Context) ([]model.TeamStats, error) {
+	defer s.lock(ctx)()
+
+	stats := make([]model.TeamStats, 0, len(s.data.teams))
+	for name := range s.data.teams {
+		stat := model.TeamStats{TeamName: name}
+		stat.TotalAssignments, stat.OpenAssignments, stat.MergedAssignments = s.data.countAssignments(func(reviewer string) bool {
+			return s.data.users[reviewer].TeamName == name
+		})
+
+		for _, e := range s.data.events {
+			if e.Type == model.AssignmentEventREASSIGNED && s.data.users[e.OldReviewerID].TeamName == name {
+				stat.Reassignments++
+			}
+		}
+
+		stat.AverageTimeToMergeSeconds = s.data.averageTimeToMerge(func(pr model.PullRequest) bool {
+			return s.data.users[pr.AuthorID].TeamName == name
+		})
+
+		stats = append(stats, stat)
+	}
+
+	slices.SortFunc(stats, func(a, b model.TeamStats) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+
+	return stats, nil
+}
+
+// GetReassignmentCounts counts reassignment events by reason.
+func (s *Storage) GetReassignmentCounts(ctx context.Context) (map[string]int, error) {
+	defer s.lock(ctx)()
+
+	counts := make(map[string]int)
+	for _, e := range s.data.events {
+		if e.Type == model.AssignmentEventREASSIGNED {
+			counts[e.Reason]++
+		}
+	}
+
+	return counts, nil
+}
+
+// GetAverageTimeToMerge averages time between creation and merge of merged pull requests.
+func (s *Storage) GetAverageTimeToMerge(ctx context.Context) (*float64, error) {
+	defer s.lock(ctx)()
+
+	return s.data.averageTimeToMerge(func(model.PullRequest) bool { return true }), nil
+}
+
+// countAssignments counts assignments of reviewers matching filter.
+func (d data) countAssignments(match func(reviewer string) bool) (total, open, merged int) {
+	for prID, reviewers := range d.assignments {
+		status := d.pullRequests[prID].Status
+		for _, reviewer := range reviewers {
+			if !match(reviewer) {
+				continue
+			}
+			total++
+			switch status {
+			case model.PullRequestStatusOPEN:
+				open++
+			case model.PullRequestStatusMERGED:
+				merged++
+			}
+		}
+	}
+	return total, open, merged
+}
+
+// averageTimeToMerge returns average number of seconds from creation to merge of merged pull requests matching filter.
+func (d data) averageTimeToMerge(match func(pr model.PullRequest) bool) *float64 {
+	var (
+		sum   float64
+		count int
+	)
+	for _, pr := range d.pullRequests {
+		if pr.Status != model.PullRequestStatusMERGED || pr.CreatedAt == nil || pr.MergedAt == nil || !match(pr) {
+			continue
+		}
+		sum += pr.MergedAt.Sub(*pr.CreatedAt).Seconds()
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+	avg := sum / float64(count)
+	return &avg
+}
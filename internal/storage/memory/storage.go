@@ -0,0 +1,148 @@
+// Package memory implements storage.Storage in memory.
+// It is meant for tests and local development and mirrors errors of postgres storage.
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage"
+)
+
+var _ storage.Storage = (*Storage)(nil)
+
+var txContextKey activeTxKey = 0
+
+type activeTxKey int
+
+// Storage keeps all data in memory.
+// Transactions are serializable: a transaction holds exclusive lock until it ends.
+type Storage struct {
+	mu   sync.Mutex
+	data data
+}
+
+type data struct {
+	teams map[string]model.Team // members are not stored here
+	// leads maps team name to ids of its leads
+	leads map[string][]string
+	users map[string]model.User
+	// pullRequests don't store assigned reviewers, see assignments
+	pullRequests map[string]model.PullRequest
+	// assignments maps pull request id to reviewers in order of assignment
+	assignments map[string][]string
+	events      []model.AssignmentEvent
+	userTokens  []userToken
+	// identities maps external login to user id
+	identities map[externalLogin]string
+	// outbox is ordered by id
+	outbox []model.OutboxMessage
+	// digests maps user id to digest subscription, users without it are subscribed
+	digests map[string]userDigest
+	// escalations maps pull request id to time it was escalated at
+	escalations map[string]time.Time
+}
+
+type userDigest struct {
+	optOut     bool
+	lastSentAt *time.Time
+}
+
+type externalLogin struct {
+	provider string
+	login    string
+}
+
+type userToken struct {
+	model.UserToken
+	hash string
+}
+
+func New() *Storage {
+	return &Storage{
+		data: data{
+			teams:        make(map[string]model.Team),
+			leads:        make(map[string][]string),
+			users:        make(map[string]model.User),
+			pullRequests: make(map[string]model.PullRequest),
+			assignments:  make(map[string][]string),
+			identities:   make(map[externalLogin]string),
+			digests:      make(map[string]userDigest),
+			escalations:  make(map[string]time.Time),
+		},
+	}
+}
+
+func (s *Storage) InTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := s.data.clone()
+	defer func() {
+		if r := recover(); r != nil {
+			s.data = snapshot
+			panic(r)
+		} else if err != nil {
+			s.data = snapshot
+		}
+	}()
+
+	return fn(context.WithValue(ctx, txContextKey, true))
+}
+
+// lock acquires storage lock unless ctx belongs to a transaction which already holds it.
+// Returned function releases the lock.
+func (s *Storage) lock(ctx context.Context) func() {
+	if inTransaction(ctx) {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txContextKey).(bool)
+	return ok
+}
+
+func (d data) clone() data {
+	assignments := make(map[string][]string, len(d.assignments))
+	for id, reviewers := range d.assignments {
+		assignments[id] = slices.Clone(reviewers)
+	}
+
+	leads := make(map[string][]string, len(d.leads))
+	for team, ids := range d.leads {
+		leads[team] = slices.Clone(ids)
+	}
+
+	return data{
+		teams:        maps.Clone(d.teams),
+		leads:        leads,
+		users:        maps.Clone(d.users),
+		pullRequests: maps.Clone(d.pullRequests),
+		assignments:  assignments,
+		events:       slices.Clone(d.events),
+		userTokens:   slices.Clone(d.userTokens),
+		identities:   maps.Clone(d.identities),
+		outbox:       slices.Clone(d.outbox),
+		digests:      maps.Clone(d.digests),
+		escalations:  maps.Clone(d.escalations),
+	}
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
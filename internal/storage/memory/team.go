@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// AddTeam adds a new team without members.
+func (s *Storage) AddTeam(ctx context.Context, name string, requiredReviewers int) (*model.Team, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.teams[name]; ok {
+		return nil, errs.TeamExistsError{TeamName: name}
+	}
+
+	team := model.Team{Name: name, RequiredReviewers: requiredReviewers}
+	s.data.teams[name] = team
+
+	team.Members = []model.TeamMember{}
+	return &team, nil
+}
+
+// GetTeam retrieves a team by name, including all its members ordered by id.
+func (s *Storage) GetTeam(ctx context.Context, name string) (*model.Team, error) {
+	defer s.lock(ctx)()
+
+	team, ok := s.data.teams[name]
+	if !ok {
+		return nil, errs.NotFoundErr
+	}
+
+	team.Members = []model.TeamMember{}
+	for _, user := range s.data.sortedUsers() {
+		if user.TeamName == name {
+			team.Members = append(team.Members, model.TeamMember{
+				UserID:     user.Id,
+				Username:   user.Username,
+				IsActive:   user.IsActive,
+				ChatHandle: user.ChatHandle,
+				Email:      user.Email,
+			})
+		}
+	}
+
+	return &team, nil
+}
+
+// SetTeamRequiredReviewers updates number of reviewers required for pull requests of team members.
+func (s *Storage) SetTeamRequiredReviewers(ctx context.Context, name string, requiredReviewers int) error {
+	defer s.lock(ctx)()
+
+	team, ok := s.data.teams[name]
+	if !ok {
+		return errs.NotFoundErr
+	}
+
+	team.RequiredReviewers = requiredReviewers
+	s.data.teams[name] = team
+
+	return nil
+}
+
+// SetTeamReviewSLA updates review SLA of team.
+func (s *Storage) SetTeamReviewSLA(ctx context.Context, name string, slaSeconds int, autoReassign bool) error {
+	defer s.lock(ctx)()
+
+	team, ok := s.data.teams[name]
+	if !ok {
+		return errs.NotFoundErr
+	}
+
+	team.ReviewSLASeconds = slaSeconds
+	team.ReviewSLAAutoReassign = autoReassign
+	s.data.teams[name] = team
+
+	return nil
+}
+
+// GetTeamLeads returns ids of leads of team ordered by id.
+func (s *Storage) GetTeamLeads(ctx context.Context, teamName string) ([]string, error) {
+	defer s.lock(ctx)()
+
+	leads := slices.Clone(s.data.leads[teamName])
+	if leads == nil {
+		leads = []string{}
+	}
+	slices.Sort(leads)
+
+	return leads, nil
+}
+
+// SetTeamLeads replaces leads of team.
+func (s *Storage) SetTeamLeads(ctx context.Context, teamName string, userIDs []string) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.teams[teamName]; !ok {
+		return errs.NotFoundErr
+	}
+	for _, id := range userIDs {
+		if _, ok := s.data.users[id]; !ok {
+			return errs.NotFoundErr
+		}
+	}
+
+	leads := slices.Clone(userIDs)
+	slices.Sort(leads)
+	s.data.leads[teamName] = slices.Compact(leads)
+
+	return nil
+}
+
+// sortedUsers returns users ordered by id to make results deterministic.
+func (d data) sortedUsers() []model.User {
+	users := make([]model.User, 0, len(d.users))
+	for _, user := range d.users {
+		users = append(users, user)
+	}
+	slices.SortFunc(users, func(a, b model.User) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return users
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// AddUserToken stores hash of user api token.
+func (s *Storage) AddUserToken(ctx context.Context, token *model.UserToken, tokenHash string) (*model.UserToken, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.users[token.UserID]; !ok {
+		return nil, errs.NotFoundErr
+	}
+	for _, t := range s.data.userTokens {
+		if t.hash == tokenHash {
+			return nil, fmt.Errorf("memory failed to add user token: token hash is not unique")
+		}
+	}
+
+	created := model.UserToken{
+		ID:        int64(len(s.data.userTokens) + 1),
+		UserID:    token.UserID,
+		Name:      token.Name,
+		CreatedAt: token.CreatedAt,
+	}
+	s.data.userTokens = append(s.data.userTokens, userToken{UserToken: created, hash: tokenHash})
+
+	return &created, nil
+}
+
+// GetUserTokens retrieves all tokens of user including revoked ones.
+func (s *Storage) GetUserTokens(ctx context.Context, userID string) ([]model.UserToken, error) {
+	defer s.lock(ctx)()
+
+	tokens := make([]model.UserToken, 0)
+	for _, t := range s.data.userTokens {
+		if t.UserID == userID {
+			token := t.UserToken
+			token.RevokedAt = cloneTime(t.RevokedAt)
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens, nil
+}
+
+// RevokeUserToken marks token as revoked. Revocation time of already revoked token is kept.
+func (s *Storage) RevokeUserToken(ctx context.Context, id int64, revokedAt time.Time) (*model.UserToken, error) {
+	defer s.lock(ctx)()
+
+	for i, t := range s.data.userTokens {
+		if t.ID != id {
+			continue
+		}
+		if t.RevokedAt == nil {
+			s.data.userTokens[i].RevokedAt = &revokedAt
+		}
+		revoked := s.data.userTokens[i].UserToken
+		revoked.RevokedAt = cloneTime(revoked.RevokedAt)
+		return &revoked, nil
+	}
+
+	return nil, errs.NotFoundErr
+}
+
+// GetUserByTokenHash finds owner of not revoked token.
+func (s *Storage) GetUserByTokenHash(ctx context.Context, tokenHash string) (*model.User, error) {
+	defer s.lock(ctx)()
+
+	for _, t := range s.data.userTokens {
+		if t.hash == tokenHash && t.RevokedAt == nil {
+			user, ok := s.data.users[t.UserID]
+			if !ok {
+				return nil, errs.NotFoundErr
+			}
+			return &user, nil
+		}
+	}
+
+	return nil, errs.NotFoundErr
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// AddUpdateUsers adds new users and updates existing ones.
+// Empty chat handle or email of existing user keeps the stored one.
+func (s *Storage) AddUpdateUsers(ctx context.Context, users []model.User) ([]model.User, error) {
+	defer s.lock(ctx)()
+
+	// validate first so failed call doesn't leave partial changes outside of transaction
+	for _, user := range users {
+		if _, ok := s.data.teams[user.TeamName]; !ok {
+			return nil, fmt.Errorf("memory failed to add user %s: team %s does not exist", user.Id, user.TeamName)
+		}
+	}
+
+	result := make([]model.User, len(users))
+	for i, user := range users {
+		if existing, ok := s.data.users[user.Id]; ok {
+			user.ChatHandle = cmp.Or(user.ChatHandle, existing.ChatHandle)
+			user.Email = cmp.Or(user.Email, existing.Email)
+		}
+		s.data.users[user.Id] = user
+		result[i] = user
+	}
+
+	return result, nil
+}
+
+// SetUserActivity updates activity of a single user by ID.
+func (s *Storage) SetUserActivity(ctx context.Context, id string, active bool) (*model.User, error) {
+	defer s.lock(ctx)()
+
+	user, ok := s.data.users[id]
+	if !ok {
+		return nil, errs.NotFoundErr
+	}
+
+	user.IsActive = active
+	s.data.users[id] = user
+
+	return &user, nil
+}
+
+// SetTeamActivity updates activity of all members of a team.
+func (s *Storage) SetTeamActivity(ctx context.Context, teamName string, active bool) ([]model.User, error) {
+	defer s.lock(ctx)()
+
+	result := make([]model.User, 0)
+	for _, user := range s.data.sortedUsers() {
+		if user.TeamName != teamName {
+			continue
+		}
+		user.IsActive = active
+		s.data.users[user.Id] = user
+		result = append(result, user)
+	}
+
+	return result, nil
+}
+
+// GetUser retrieves a single user by ID.
+func (s *Storage) GetUser(ctx context.Context, id string) (*model.User, error) {
+	defer s.lock(ctx)()
+
+	user, ok := s.data.users[id]
+	if !ok {
+		return nil, errs.NotFoundErr
+	}
+
+	return &user, nil
+}
+
+// GetActiveColleges finds IDs of all active users belonging to the same team as the given userID (excluding userID itself).
+func (s *Storage) GetActiveColleges(ctx context.Context, userID string) ([]string, error) {
+	defer s.lock(ctx)()
+
+	colleges := make([]string, 0)
+
+	user, ok := s.data.users[userID]
+	if !ok {
+		return colleges, nil
+	}
+
+	for _, college := range s.data.sortedUsers() {
+		if college.IsActive && college.TeamName == user.TeamName && college.Id != userID {
+			colleges = append(colleges, college.Id)
+		}
+	}
+
+	return colleges, nil
+}
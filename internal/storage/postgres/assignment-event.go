@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage/postgres/dao"
+)
+
+// AddAssignmentEvents appends events to review assignment history.
+func (s *Storage) AddAssignmentEvents(ctx context.Context, events []model.AssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	builder := squirrelBuilder.Insert("assignment_events").
+		Columns("pull_request_id", "type", "actor", "old_reviewer_id", "new_reviewer_id", "reason", "created_at")
+	for _, e := range events {
+		builder = builder.Values(e.PullRequestID, e.Type, e.Actor, nullIfEmpty(e.OldReviewerID), nullIfEmpty(e.NewReviewerID), e.Reason, e.CreatedAt)
+	}
+
+	q, vals, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("squirrel failed to build query: %w", err)
+	}
+
+	if _, err = s.getExecutor(ctx).Exec(ctx, q, vals...); err != nil {
+		return fmt.Errorf("postgres failed to execute insert assignment events query: %w", err)
+	}
+	return nil
+}
+
+// GetAssignmentEvents retrieves review assignment history of pull request.
+func (s *Storage) GetAssignmentEvents(ctx context.Context, prID string) ([]model.AssignmentEvent, error) {
+	q := `SELECT * FROM assignment_events WHERE pull_request_id = $1 ORDER BY id`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, prID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute get assignment events query: %w", err)
+	}
+	defer rows.Close()
+
+	daoEvents, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.AssignmentEvent])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	events := make([]model.AssignmentEvent, len(daoEvents))
+	for i, daoEvent := range daoEvents {
+		events[i] = daoEvent.ToModel()
+	}
+
+	return events, nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,37 @@
+package dao
+
+import (
+	"time"
+
+	"review-assigner/internal/model"
+)
+
+// AssignmentEvent maps to 'assignment_events' table.
+type AssignmentEvent struct {
+	ID            int64                     `db:"id"`
+	PullRequestID string                    `db:"pull_request_id"`
+	Type          model.AssignmentEventType `db:"type"`
+	Actor         string                    `db:"actor"`
+	OldReviewerID *string                   `db:"old_reviewer_id"`
+	NewReviewerID *string                   `db:"new_reviewer_id"`
+	Reason        string                    `db:"reason"`
+	CreatedAt     time.Time                 `db:"created_at"`
+}
+
+func (e AssignmentEvent) ToModel() model.AssignmentEvent {
+	event := model.AssignmentEvent{
+		ID:            e.ID,
+		PullRequestID: e.PullRequestID,
+		Type:          e.Type,
+		Actor:         e.Actor,
+		Reason:        e.Reason,
+		CreatedAt:     e.CreatedAt,
+	}
+	if e.OldReviewerID != nil {
+		event.OldReviewerID = *e.OldReviewerID
+	}
+	if e.NewReviewerID != nil {
+		event.NewReviewerID = *e.NewReviewerID
+	}
+	return event
+}
@@ -0,0 +1,14 @@
+package dao
+
+import "review-assigner/internal/model"
+
+// ExternalIdentity maps to 'external_identities' table.
+type ExternalIdentity struct {
+	Provider string `db:"provider"`
+	Login    string `db:"login"`
+	UserID   string `db:"user_id"`
+}
+
+func (i ExternalIdentity) ToModel() model.ExternalIdentity {
+	return model.ExternalIdentity(i)
+}
@@ -3,15 +3,19 @@ package dao
 import "review-assigner/internal/model"
 
 type Member struct {
-	UserID   string `db:"id"`
-	Username string `db:"username"`
-	IsActive bool   `db:"is_active"`
+	UserID     string `db:"id"`
+	Username   string `db:"username"`
+	IsActive   bool   `db:"is_active"`
+	ChatHandle string `db:"chat_handle"`
+	Email      string `db:"email"`
 }
 
 func (m Member) ToModel() model.TeamMember {
 	return model.TeamMember{
-		UserID:   m.UserID,
-		Username: m.Username,
-		IsActive: m.IsActive,
+		UserID:     m.UserID,
+		Username:   m.Username,
+		IsActive:   m.IsActive,
+		ChatHandle: m.ChatHandle,
+		Email:      m.Email,
 	}
 }
@@ -0,0 +1,26 @@
+package dao
+
+import (
+	"encoding/json"
+	"time"
+
+	"review-assigner/internal/model"
+)
+
+// OutboxMessage maps to 'outbox' table.
+type OutboxMessage struct {
+	ID            int64                     `db:"id"`
+	Sink          string                    `db:"sink"`
+	Type          string                    `db:"type"`
+	Payload       json.RawMessage           `db:"payload"`
+	Status        model.OutboxMessageStatus `db:"status"`
+	Attempts      int                       `db:"attempts"`
+	NextAttemptAt time.Time                 `db:"next_attempt_at"`
+	LastError     string                    `db:"last_error"`
+	CreatedAt     time.Time                 `db:"created_at"`
+	ProcessedAt   *time.Time                `db:"processed_at"`
+}
+
+func (m OutboxMessage) ToModel() model.OutboxMessage {
+	return model.OutboxMessage(m)
+}
@@ -8,12 +8,26 @@ import (
 
 // PullRequest maps to 'pull_requests' table.
 type PullRequest struct {
-	ID        string                  `db:"id"`
-	Name      string                  `db:"name"`
-	AuthorID  string                  `db:"author_id"`
-	Status    model.PullRequestStatus `db:"status"`
-	CreatedAt *time.Time              `db:"created_at"`
-	MergedAt  *time.Time              `db:"merged_at"`
+	ID                string                  `db:"id"`
+	Name              string                  `db:"name"`
+	AuthorID          string                  `db:"author_id"`
+	Status            model.PullRequestStatus `db:"status"`
+	CreatedAt         *time.Time              `db:"created_at"`
+	MergedAt          *time.Time              `db:"merged_at"`
+	NeedMoreReviewers bool                    `db:"need_more_reviewers"`
+}
+
+func (pr PullRequest) ToModel(assignedReviewers []string) model.PullRequest {
+	return model.PullRequest{
+		Id:                pr.ID,
+		Name:              pr.Name,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: assignedReviewers,
+		CreatedAt:         pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+		NeedMoreReviewers: pr.NeedMoreReviewers,
+	}
 }
 
 type PullRequestShort struct {
@@ -0,0 +1,30 @@
+package dao
+
+import "review-assigner/internal/model"
+
+type UserStats struct {
+	UserID            string `db:"user_id"`
+	Username          string `db:"username"`
+	TeamName          string `db:"team_name"`
+	TotalAssignments  int    `db:"total_assignments"`
+	OpenAssignments   int    `db:"open_assignments"`
+	MergedAssignments int    `db:"merged_assignments"`
+	ReassignedFrom    int    `db:"reassigned_from"`
+}
+
+func (s UserStats) ToModel() model.UserStats {
+	return model.UserStats(s)
+}
+
+type TeamStats struct {
+	TeamName                  string   `db:"team_name"`
+	TotalAssignments          int      `db:"total_assignments"`
+	OpenAssignments           int      `db:"open_assignments"`
+	MergedAssignments         int      `db:"merged_assignments"`
+	Reassignments             int      `db:"reassignments"`
+	AverageTimeToMergeSeconds *float64 `db:"average_time_to_merge_seconds"`
+}
+
+func (s TeamStats) ToModel() model.TeamStats {
+	return model.TeamStats(s)
+}
@@ -2,5 +2,8 @@ package dao
 
 // Team maps to 'teams' table.
 type Team struct {
-	Name string `db:"name"`
+	Name                  string `db:"name"`
+	RequiredReviewers     int    `db:"required_reviewers"`
+	ReviewSLASeconds      int    `db:"review_sla_seconds"`
+	ReviewSLAAutoReassign bool   `db:"review_sla_auto_reassign"`
 }
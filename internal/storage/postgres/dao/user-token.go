@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"time"
+
+	"review-assigner/internal/model"
+)
+
+// UserToken maps to 'user_tokens' table without token_hash column.
+type UserToken struct {
+	ID        int64      `db:"id"`
+	UserID    string     `db:"user_id"`
+	Name      string     `db:"name"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+func (t UserToken) ToModel() model.UserToken {
+	return model.UserToken(t)
+}
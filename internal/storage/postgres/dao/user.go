@@ -8,13 +8,18 @@ type User struct {
 	Username string `db:"username"`
 	TeamName string `db:"team_name"`
 	IsActive bool   `db:"is_active"`
+	// ChatHandle is empty if user has no handle
+	ChatHandle string `db:"chat_handle"`
+	Email      string `db:"email"`
 }
 
 func (u User) ToModel() model.User {
 	return model.User{
-		Id:       u.ID,
-		Username: u.Username,
-		TeamName: u.TeamName,
-		IsActive: u.IsActive,
+		Id:         u.ID,
+		Username:   u.Username,
+		TeamName:   u.TeamName,
+		IsActive:   u.IsActive,
+		ChatHandle: u.ChatHandle,
+		Email:      u.Email,
 	}
 }
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage/postgres/dao"
+)
+
+// SetDigestOptOut unsubscribes user from digests or subscribes back.
+func (s *Storage) SetDigestOptOut(ctx context.Context, userID string, optOut bool) error {
+	q := `INSERT INTO user_digests (user_id, opt_out) VALUES ($1, $2)
+		  ON CONFLICT (user_id) DO UPDATE SET opt_out = excluded.opt_out`
+	if _, err := s.getExecutor(ctx).Exec(ctx, q, userID, optOut); err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == ForeignKeyViolationErr {
+			return errs.NotFoundErr
+		}
+		return fmt.Errorf("postgres failed to execute upsert digest opt out query: %w", err)
+	}
+	return nil
+}
+
+// GetDigestRecipients retrieves active users with email who haven't opted out of digests.
+func (s *Storage) GetDigestRecipients(ctx context.Context) ([]model.User, error) {
+	q := `SELECT u.* FROM users u
+		  LEFT JOIN user_digests d ON d.user_id = u.id
+		  WHERE u.is_active AND u.email <> '' AND NOT COALESCE(d.opt_out, FALSE)
+		  ORDER BY u.id`
+	rows, err := s.getExecutor(ctx).Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute get digest recipients query: %w", err)
+	}
+	defer rows.Close()
+
+	daoUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.User])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	result := make([]model.User, len(daoUsers))
+	for i, daoUser := range daoUsers {
+		result[i] = daoUser.ToModel()
+	}
+
+	return result, nil
+}
+
+// ClaimDigest sets time of last digest of user unless digest was already sent not before since.
+func (s *Storage) ClaimDigest(ctx context.Context, userID string, sentAt, since time.Time) (bool, error) {
+	q := `INSERT INTO user_digests (user_id, last_sent_at) VALUES ($1, $2)
+		  ON CONFLICT (user_id) DO UPDATE SET last_sent_at = excluded.last_sent_at
+		  WHERE user_digests.last_sent_at IS NULL OR user_digests.last_sent_at < $3`
+	tag, err := s.getExecutor(ctx).Exec(ctx, q, userID, sentAt, since)
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == ForeignKeyViolationErr {
+			return false, errs.NotFoundErr
+		}
+		return false, fmt.Errorf("postgres failed to execute claim digest query: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
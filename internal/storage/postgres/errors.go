@@ -1,5 +1,6 @@
 package postgres
 
 const (
-	UniqueViolationErr = "23505"
+	UniqueViolationErr     = "23505"
+	ForeignKeyViolationErr = "23503"
 )
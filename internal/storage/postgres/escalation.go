@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"review-assigner/internal/errs"
+)
+
+// GetStalePullRequestIDs retrieves ids of open pull requests which exceeded review SLA of author's team
+// and are not escalated yet, oldest first.
+func (s *Storage) GetStalePullRequestIDs(ctx context.Context, now time.Time) ([]string, error) {
+	q := `SELECT pr.id FROM pull_requests pr
+		  JOIN users u ON u.id = pr.author_id
+		  JOIN teams t ON t.name = u.team_name
+		  LEFT JOIN pull_request_escalations e ON e.pull_request_id = pr.id
+		  WHERE pr.status = 'OPEN' AND t.review_sla_seconds > 0 AND e.pull_request_id IS NULL
+			AND pr.created_at + make_interval(secs => t.review_sla_seconds) <= $1
+		  ORDER BY pr.created_at, pr.id`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, now)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute get stale pull requests query: %w", err)
+	}
+	defer rows.Close()
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+// AddPullRequestEscalation records escalation of pull request unless it is already escalated.
+func (s *Storage) AddPullRequestEscalation(ctx context.Context, prID string, escalatedAt time.Time) (bool, error) {
+	q := `INSERT INTO pull_request_escalations (pull_request_id, escalated_at) VALUES ($1, $2)
+		  ON CONFLICT (pull_request_id) DO NOTHING`
+	tag, err := s.getExecutor(ctx).Exec(ctx, q, prID, escalatedAt)
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == ForeignKeyViolationErr {
+			return false, errs.NotFoundErr
+		}
+		return false, fmt.Errorf("postgres failed to execute insert pull request escalation query: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
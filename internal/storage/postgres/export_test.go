@@ -0,0 +1,9 @@
+package postgres
+
+import "context"
+
+// ForgetMigrations clears schema_migrations, as if schema was created without migrations runner.
+func (s *Storage) ForgetMigrations(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM schema_migrations`)
+	return err
+}
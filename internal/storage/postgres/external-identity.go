@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage/postgres/dao"
+)
+
+// SetExternalIdentity maps login to user, replacing previous mapping of login.
+func (s *Storage) SetExternalIdentity(ctx context.Context, identity model.ExternalIdentity) error {
+	q := `INSERT INTO external_identities (provider, login, user_id) VALUES ($1, $2, $3)
+		  ON CONFLICT (provider, login) DO UPDATE SET user_id = excluded.user_id`
+	if _, err := s.getExecutor(ctx).Exec(ctx, q, identity.Provider, identity.Login, identity.UserID); err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == ForeignKeyViolationErr {
+			return errs.NotFoundErr
+		}
+		return fmt.Errorf("postgres failed to execute upsert external identity query: %w", err)
+	}
+	return nil
+}
+
+// GetExternalIdentities retrieves identities of user.
+func (s *Storage) GetExternalIdentities(ctx context.Context, userID string) ([]model.ExternalIdentity, error) {
+	q := `SELECT provider, login, user_id FROM external_identities WHERE user_id = $1 ORDER BY provider, login`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute get external identities query: %w", err)
+	}
+	defer rows.Close()
+
+	daoIdentities, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.ExternalIdentity])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	identities := make([]model.ExternalIdentity, len(daoIdentities))
+	for i, daoIdentity := range daoIdentities {
+		identities[i] = daoIdentity.ToModel()
+	}
+
+	return identities, nil
+}
+
+// DeleteExternalIdentity removes mapping of login.
+func (s *Storage) DeleteExternalIdentity(ctx context.Context, provider, login string) error {
+	q := `DELETE FROM external_identities WHERE provider = $1 AND login = $2`
+	tag, err := s.getExecutor(ctx).Exec(ctx, q, provider, login)
+	if err != nil {
+		return fmt.Errorf("postgres failed to execute delete external identity query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.NotFoundErr
+	}
+	return nil
+}
+
+// GetUserByExternalIdentity finds user mapped to login.
+func (s *Storage) GetUserByExternalIdentity(ctx context.Context, provider, login string) (*model.User, error) {
+	q := `SELECT u.* FROM users u
+		  JOIN external_identities i ON i.user_id = u.id
+		  WHERE i.provider = $1 AND i.login = $2`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, provider, login)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	daoUser, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dao.User])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("pgx failed to collect one row: %w", err)
+	}
+
+	user := daoUser.ToModel()
+
+	return &user, nil
+}
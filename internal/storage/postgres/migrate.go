@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"review-assigner/migrations"
+)
+
+// migrationsLockID is key of advisory lock which is held while migrations run,
+// so replicas starting at the same time don't apply them concurrently.
+const migrationsLockID int64 = 0x7265766965770001
+
+// MigrationStatus describes migration and whether it is applied.
+// AppliedAt is nil for pending migrations.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// MigrateUp applies all pending migrations in order of versions.
+func (s *Storage) MigrateUp(ctx context.Context) error {
+	all, err := loadMigrations(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	return s.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range all {
+			if _, ok := applied[m.version]; ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, m.up); err != nil {
+					return fmt.Errorf("postgres failed to apply migration %03d_%s: %w", m.version, m.name, err)
+				}
+				q := `INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, now())`
+				if _, err := tx.Exec(ctx, q, m.version, m.name); err != nil {
+					return fmt.Errorf("postgres failed to record migration %03d_%s: %w", m.version, m.name, err)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			slog.Info("applied migration", "version", m.version, "name", m.name)
+		}
+
+		return nil
+	})
+}
+
+// MigrateDown rolls back steps last applied migrations.
+func (s *Storage) MigrateDown(ctx context.Context, steps int) error {
+	all, err := loadMigrations(migrations.FS)
+	if err != nil {
+		return err
+	}
+	byVersion := make(map[int]migration, len(all))
+	for _, m := range all {
+		byVersion[m.version] = m
+	}
+
+	return s.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]int, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		slices.Sort(versions)
+		slices.Reverse(versions)
+
+		for _, version := range versions[:min(steps, len(versions))] {
+			m, ok := byVersion[version]
+			if !ok {
+				return fmt.Errorf("migration %03d is applied but its files are missing", version)
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, m.down); err != nil {
+					return fmt.Errorf("postgres failed to roll back migration %03d_%s: %w", m.version, m.name, err)
+				}
+				q := `DELETE FROM schema_migrations WHERE version = $1`
+				if _, err := tx.Exec(ctx, q, m.version); err != nil {
+					return fmt.Errorf("postgres failed to record migration %03d_%s rollback: %w", m.version, m.name, err)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			slog.Info("rolled back migration", "version", m.version, "name", m.name)
+		}
+
+		return nil
+	})
+}
+
+// MigrationStatus lists known migrations in order of versions.
+func (s *Storage) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	all, err := loadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []MigrationStatus
+	err = s.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		result = make([]MigrationStatus, len(all))
+		for i, m := range all {
+			result[i] = MigrationStatus{Version: m.version, Name: m.name}
+			if appliedAt, ok := applied[m.version]; ok {
+				result[i].AppliedAt = &appliedAt
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// withMigrationLock runs fn on a dedicated connection holding migrations advisory lock.
+// schema_migrations table is created if it does not exist.
+func (s *Storage) withMigrationLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres pool failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationsLockID); err != nil {
+		return fmt.Errorf("postgres failed to acquire migrations lock: %w", err)
+	}
+	defer func() {
+		// lock is bound to session, so it must be released even if ctx is canceled
+		if _, err := conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationsLockID); err != nil {
+			slog.Error("failed to release migrations lock", "error", err)
+		}
+	}()
+
+	q := `CREATE TABLE IF NOT EXISTS schema_migrations
+		  (
+		      version    INTEGER PRIMARY KEY,
+		      name       VARCHAR(255) NOT NULL,
+		      applied_at TIMESTAMPTZ  NOT NULL
+		  )`
+	if _, err := conn.Exec(ctx, q); err != nil {
+		return fmt.Errorf("postgres failed to create schema_migrations table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// appliedMigrations returns applied versions with time they were applied.
+func appliedMigrations(ctx context.Context, conn *pgxpool.Conn) (map[int]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+
+	var (
+		version   int
+		appliedAt time.Time
+	)
+	_, err = pgx.ForEachRow(rows, []any{&version, &appliedAt}, func() error {
+		applied[version] = appliedAt
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to read applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// loadMigrations reads NNN_name.up.sql and NNN_name.down.sql files from fsys
+// and returns migrations sorted by version.
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*migration)
+	for _, fileName := range names {
+		base := strings.TrimSuffix(fileName, ".sql")
+		base, direction, ok := cutLast(base, ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %q", fileName)
+		}
+		rawVersion, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %q", fileName)
+		}
+		version, err := strconv.Atoi(rawVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version of migration %q: %w", fileName, err)
+		}
+
+		content, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", fileName, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: name}
+			byVersion[version] = m
+		} else if m.name != name {
+			return nil, fmt.Errorf("migrations %q and %q have the same version", m.name, name)
+		}
+		if direction == "up" {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	result := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migration %03d_%s must have both up and down files", m.version, m.name)
+		}
+		result = append(result, *m)
+	}
+	slices.SortFunc(result, func(a, b migration) int {
+		return a.version - b.version
+	})
+
+	return result, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage/postgres/dao"
+)
+
+// AddOutboxMessages adds messages to outbox.
+func (s *Storage) AddOutboxMessages(ctx context.Context, messages []model.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	builder := squirrelBuilder.Insert("outbox").
+		Columns("sink", "type", "payload", "status", "attempts", "next_attempt_at", "last_error", "created_at")
+	for _, m := range messages {
+		builder = builder.Values(m.Sink, m.Type, string(m.Payload), m.Status, m.Attempts, m.NextAttemptAt, m.LastError, m.CreatedAt)
+	}
+
+	q, vals, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("squirrel failed to build query: %w", err)
+	}
+
+	if _, err = s.getExecutor(ctx).Exec(ctx, q, vals...); err != nil {
+		return fmt.Errorf("postgres failed to execute insert outbox messages query: %w", err)
+	}
+	return nil
+}
+
+// ClaimOutboxMessages leases due messages. Rows locked by concurrent claims are skipped,
+// so dispatchers of several replicas don't process the same message.
+func (s *Storage) ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxMessage, error) {
+	q := `WITH claimed AS (
+		      UPDATE outbox SET next_attempt_at = $2
+		      WHERE id IN (
+		          SELECT id FROM outbox
+		          WHERE status = 'PENDING' AND next_attempt_at <= $1
+		          ORDER BY next_attempt_at, id LIMIT $3
+		          FOR UPDATE SKIP LOCKED
+		      )
+		      RETURNING *
+		  )
+		  SELECT * FROM claimed ORDER BY id`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute claim outbox messages query: %w", err)
+	}
+	defer rows.Close()
+
+	daoMessages, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.OutboxMessage])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	messages := make([]model.OutboxMessage, len(daoMessages))
+	for i, daoMessage := range daoMessages {
+		messages[i] = daoMessage.ToModel()
+	}
+
+	return messages, nil
+}
+
+// UpdateOutboxMessage saves outcome of processing attempt.
+func (s *Storage) UpdateOutboxMessage(ctx context.Context, message *model.OutboxMessage) error {
+	q := `UPDATE outbox
+		  SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, processed_at = $6
+		  WHERE id = $1`
+	tag, err := s.getExecutor(ctx).Exec(ctx, q, message.ID, message.Status, message.Attempts,
+		message.NextAttemptAt, message.LastError, message.ProcessedAt)
+	if err != nil {
+		return fmt.Errorf("postgres failed to execute update outbox message query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.NotFoundErr
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage/postgres/pgtest"
+)
+
+func TestClaimOutboxMessagesSkipLocked(t *testing.T) {
+	st := pgtest.New(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	messages := make([]model.OutboxMessage, 2)
+	for i := range messages {
+		messages[i] = model.OutboxMessage{
+			Sink:          "webhook",
+			Type:          string(model.NotificationReviewAssigned),
+			Payload:       []byte(`{}`),
+			Status:        model.OutboxMessagePENDING,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+	}
+	if err := st.AddOutboxMessages(ctx, messages); err != nil {
+		t.Fatalf("AddOutboxMessages: %v", err)
+	}
+
+	// first message stays locked until transaction ends, so another dispatcher skips it instead of waiting
+	err := st.InTransaction(ctx, func(txCtx context.Context) error {
+		locked, err := st.ClaimOutboxMessages(txCtx, now, time.Minute, 1)
+		if err != nil {
+			t.Fatalf("ClaimOutboxMessages in transaction: %v", err)
+		}
+		if len(locked) != 1 {
+			t.Fatalf("want one message claimed, got %+v", locked)
+		}
+
+		// the same lease time, so only lock keeps first message from being claimed again
+		otherCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		other, err := st.ClaimOutboxMessages(otherCtx, now.Add(time.Hour), time.Minute, 10)
+		if err != nil {
+			t.Fatalf("ClaimOutboxMessages outside transaction: %v", err)
+		}
+		if len(other) != 1 || other[0].ID == locked[0].ID {
+			t.Fatalf("want the other message, got %+v while %d is locked", other, locked[0].ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("InTransaction: %v", err)
+	}
+}
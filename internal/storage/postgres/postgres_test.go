@@ -0,0 +1,29 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"review-assigner/internal/storage"
+	"review-assigner/internal/storage/postgres/pgtest"
+	"review-assigner/internal/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return pgtest.New(t)
+	})
+}
+
+func TestMigrateUpRerun(t *testing.T) {
+	st := pgtest.New(t)
+	ctx := context.Background()
+
+	// every migration must be re-runnable on schema it has already created
+	if err := st.ForgetMigrations(ctx); err != nil {
+		t.Fatalf("ForgetMigrations: %v", err)
+	}
+	if err := st.MigrateUp(ctx); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+}
@@ -19,60 +19,56 @@ func (s *Storage) CreatePullRequestWithAssignments(ctx context.Context, pr *mode
 	err := s.InTransaction(ctx, func(ctx context.Context) error {
 		e := s.getExecutor(ctx)
 
-		qPR := `INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at) 
-		  VALUES ($1, $2, $3, $4, $5, $6) RETURNING *`
-		rowsPR, err := e.Query(ctx, qPR, pr.Id, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt)
+		qPR := `INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at, need_more_reviewers) 
+		  VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`
+		rowsPR, err := e.Query(ctx, qPR, pr.Id, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt, pr.NeedMoreReviewers)
 		if err != nil {
-			var pgxError *pgconn.PgError
-			if errors.As(err, &pgxError) && pgxError.Code == UniqueViolationErr {
-				return errs.PullRequestExistsError{PullRequestID: pr.Id}
-			}
 			return fmt.Errorf("postgres failed to execute insert query for pull request: %w", err)
 		}
 		defer rowsPR.Close()
 
 		daoPR, err := pgx.CollectOneRow(rowsPR, pgx.RowToStructByName[dao.PullRequest])
 		if err != nil {
+			var pgxError *pgconn.PgError
+			if errors.As(err, &pgxError) && pgxError.Code == UniqueViolationErr {
+				return errs.PullRequestExistsError{PullRequestID: pr.Id}
+			}
 			return fmt.Errorf("postgres failed to collect dao pull request row: %w", err)
 		}
 
-		vals := make([]any, 0, 2)
-		for _, reviewer := range pr.AssignedReviewers {
-			vals = append(vals, reviewer, pr.Id)
-		}
+		assignedReviewers := make([]string, 0, len(pr.AssignedReviewers))
 
-		builder := squirrelBuilder.Insert("review_assignments").
-			Columns("user_id", "pull_request_id").
-			Values(vals...).
-			Suffix("RETURNING *")
-		qAssignments, vals, err := builder.ToSql()
+		if len(pr.AssignedReviewers) > 0 {
+			builder := squirrelBuilder.Insert("review_assignments").
+				Columns("user_id", "pull_request_id").
+				Suffix("RETURNING *")
+			for _, reviewer := range pr.AssignedReviewers {
+				builder = builder.Values(reviewer, pr.Id)
+			}
 
-		rowsAssignments, err := e.Query(ctx, qAssignments, vals...)
-		if err != nil {
-			return fmt.Errorf("postgres failed to execute insert query for review assignments: %w", err)
-		}
-		defer rowsAssignments.Close()
+			qAssignments, vals, err := builder.ToSql()
+			if err != nil {
+				return fmt.Errorf("squirrel failed to build query: %w", err)
+			}
 
-		daoAssignments, err := pgx.CollectRows(rowsAssignments, pgx.RowToStructByName[dao.ReviewAssignment])
-		if err != nil {
-			return fmt.Errorf("postgres failed to collect dao assignments: %w", err)
-		}
+			rowsAssignments, err := e.Query(ctx, qAssignments, vals...)
+			if err != nil {
+				return fmt.Errorf("postgres failed to execute insert query for review assignments: %w", err)
+			}
+			defer rowsAssignments.Close()
 
-		assignedReviewers := make([]string, 0, 2)
-		for _, assignment := range daoAssignments {
-			assignedReviewers = append(assignedReviewers, assignment.UserID)
-		}
+			daoAssignments, err := pgx.CollectRows(rowsAssignments, pgx.RowToStructByName[dao.ReviewAssignment])
+			if err != nil {
+				return fmt.Errorf("postgres failed to collect dao assignments: %w", err)
+			}
 
-		createdPR = model.PullRequest{
-			Id:                daoPR.ID,
-			Name:              daoPR.Name,
-			AuthorID:          daoPR.AuthorID,
-			Status:            daoPR.Status,
-			AssignedReviewers: assignedReviewers,
-			CreatedAt:         daoPR.CreatedAt,
-			MergedAt:          daoPR.MergedAt,
+			for _, assignment := range daoAssignments {
+				assignedReviewers = append(assignedReviewers, assignment.UserID)
+			}
 		}
 
+		createdPR = daoPR.ToModel(assignedReviewers)
+
 		return nil
 	})
 
@@ -99,18 +95,12 @@ func (s *Storage) GetPullRequest(ctx context.Context, id string) (*model.PullReq
 
 		daoPR, err := pgx.CollectOneRow(rowsPR, pgx.RowToStructByName[dao.PullRequest])
 		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errs.NotFoundErr
+			}
 			return fmt.Errorf("postgres failed to collect one row: %w", err)
 		}
 
-		result = model.PullRequest{
-			Id:        daoPR.ID,
-			Name:      daoPR.Name,
-			AuthorID:  daoPR.AuthorID,
-			Status:    daoPR.Status,
-			CreatedAt: daoPR.CreatedAt,
-			MergedAt:  daoPR.MergedAt,
-		}
-
 		qAssignments := `SELECT * FROM review_assignments WHERE pull_request_id = $1`
 		rowsAssignments, err := e.Query(ctx, qAssignments, id)
 		if err != nil {
@@ -131,7 +121,7 @@ func (s *Storage) GetPullRequest(ctx context.Context, id string) (*model.PullReq
 			assignments[i] = daoAssignment.UserID
 		}
 
-		result.AssignedReviewers = assignments
+		result = daoPR.ToModel(assignments)
 
 		return nil
 	})
@@ -144,9 +134,9 @@ func (s *Storage) GetPullRequest(ctx context.Context, id string) (*model.PullReq
 
 func (s *Storage) UpdatePullRequest(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
 	q := `UPDATE pull_requests
-		  SET name = $2, author_id = $3, status = $4, created_at = $5, merged_at = $6
+		  SET name = $2, author_id = $3, status = $4, created_at = $5, merged_at = $6, need_more_reviewers = $7
 		  WHERE id = $1 RETURNING *`
-	rows, err := s.getExecutor(ctx).Query(ctx, q, pr.Id, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt)
+	rows, err := s.getExecutor(ctx).Query(ctx, q, pr.Id, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt, pr.NeedMoreReviewers)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.NotFoundErr
@@ -157,18 +147,95 @@ func (s *Storage) UpdatePullRequest(ctx context.Context, pr *model.PullRequest)
 
 	daoPR, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dao.PullRequest])
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
 		return nil, fmt.Errorf("postgres failed to collect one row: %w", err)
 	}
 
-	updatedPR := &model.PullRequest{
-		Id:                daoPR.ID,
-		Name:              daoPR.Name,
-		AuthorID:          daoPR.AuthorID,
-		Status:            daoPR.Status,
-		AssignedReviewers: pr.AssignedReviewers,
-		CreatedAt:         daoPR.CreatedAt,
-		MergedAt:          daoPR.MergedAt,
+	updatedPR := daoPR.ToModel(pr.AssignedReviewers)
+
+	return &updatedPR, nil
+}
+
+// GetUnderstaffedPullRequests finds open pull requests marked as needing more reviewers.
+func (s *Storage) GetUnderstaffedPullRequests(ctx context.Context, teamName string) ([]model.PullRequest, error) {
+	var result []model.PullRequest
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		e := s.getExecutor(ctx)
+
+		qPRs := `SELECT pr.* FROM pull_requests pr
+			  JOIN users u ON u.id = pr.author_id
+			  WHERE pr.status = 'OPEN' AND pr.need_more_reviewers AND ($1 = '' OR u.team_name = $1)
+			  ORDER BY pr.created_at`
+		rowsPRs, err := e.Query(ctx, qPRs, teamName)
+		if err != nil {
+			return fmt.Errorf("postgres failed to execute understaffed pull requests query: %w", err)
+		}
+		defer rowsPRs.Close()
+
+		daoPRs, err := pgx.CollectRows(rowsPRs, pgx.RowToStructByName[dao.PullRequest])
+		if err != nil {
+			return fmt.Errorf("postgres failed to collect rows: %w", err)
+		}
+
+		ids := make([]string, len(daoPRs))
+		for i, daoPR := range daoPRs {
+			ids[i] = daoPR.ID
+		}
+
+		reviewers, err := s.getReviewers(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		result = make([]model.PullRequest, len(daoPRs))
+		for i, daoPR := range daoPRs {
+			result[i] = daoPR.ToModel(reviewers[daoPR.ID])
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateNeedMoreReviewers recomputes need_more_reviewers of open pull requests of team authors.
+func (s *Storage) UpdateNeedMoreReviewers(ctx context.Context, teamName string, requiredReviewers int) error {
+	q := `UPDATE pull_requests pr
+		  SET need_more_reviewers = (SELECT count(*) FROM review_assignments ra WHERE ra.pull_request_id = pr.id) < $2
+		  FROM users u
+		  WHERE u.id = pr.author_id AND u.team_name = $1 AND pr.status = 'OPEN'`
+	if _, err := s.getExecutor(ctx).Exec(ctx, q, teamName, requiredReviewers); err != nil {
+		return fmt.Errorf("postgres failed to execute update need more reviewers query: %w", err)
+	}
+	return nil
+}
+
+// getReviewers returns assigned reviewers of each of pull requests.
+func (s *Storage) getReviewers(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	q := `SELECT * FROM review_assignments WHERE pull_request_id = ANY($1)`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, prIDs)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to get review assignments: %w", err)
+	}
+	defer rows.Close()
+
+	daoAssignments, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.ReviewAssignment])
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to collect rows: %w", err)
+	}
+
+	reviewers := make(map[string][]string, len(prIDs))
+	for _, id := range prIDs {
+		reviewers[id] = []string{}
+	}
+	for _, a := range daoAssignments {
+		reviewers[a.PullRequestID] = append(reviewers[a.PullRequestID], a.UserID)
 	}
 
-	return updatedPR, nil
+	return reviewers, nil
 }
@@ -2,10 +2,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
+	"review-assigner/internal/errs"
 	"review-assigner/internal/model"
 	"review-assigner/internal/storage/postgres/dao"
 )
@@ -19,14 +22,28 @@ func (s *Storage) DeleteReviewAssignment(ctx context.Context, prID string, userI
 	return nil
 }
 
+// AddReviewAssignment assigns user to pull request as a reviewer.
 func (s *Storage) AddReviewAssignment(ctx context.Context, prID string, userID string) (reviewerID string, err error) {
-	//TODO implement me
-	panic("implement me")
+	q := `INSERT INTO review_assignments (user_id, pull_request_id) VALUES ($1, $2) RETURNING user_id`
+	err = s.getExecutor(ctx).QueryRow(ctx, q, userID, prID).Scan(&reviewerID)
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) {
+			switch pgxError.Code {
+			case UniqueViolationErr:
+				return "", errs.ReviewAssignmentExistsError{PullRequestID: prID, UserID: userID}
+			case ForeignKeyViolationErr:
+				return "", errs.NotFoundErr
+			}
+		}
+		return "", fmt.Errorf("postgres failed to execute insert review assignment query: %w", err)
+	}
+	return reviewerID, nil
 }
 
 func (s *Storage) GetUserAssignments(ctx context.Context, userID string) ([]model.PullRequestShort, error) {
 	q := `SELECT id, name, author_id, status FROM pull_requests
-		  WHERE id = 
+		  WHERE id IN
 		        (SELECT pull_request_id FROM review_assignments WHERE user_id = $1)`
 	rows, err := s.getExecutor(ctx).Query(ctx, q, userID)
 	if err != nil {
@@ -51,3 +68,35 @@ func (s *Storage) GetUserAssignments(ctx context.Context, userID string) ([]mode
 
 	return prs, nil
 }
+
+// GetOpenAssignmentCounts counts review assignments on not merged pull requests for each of userIDs.
+func (s *Storage) GetOpenAssignmentCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	q := `SELECT ra.user_id, COUNT(*) FROM review_assignments ra
+		  JOIN pull_requests pr ON pr.id = ra.pull_request_id
+		  WHERE pr.status <> 'MERGED' AND ra.user_id = ANY($1)
+		  GROUP BY ra.user_id`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute open assignment counts query: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		counts[id] = 0
+	}
+
+	var (
+		userID string
+		count  int
+	)
+	_, err = pgx.ForEachRow(rows, []any{&userID, &count}, func() error {
+		counts[userID] = count
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to read open assignment counts: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,72 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage/postgres/pgtest"
+)
+
+func TestAddReviewAssignment(t *testing.T) {
+	st := pgtest.New(t)
+	ctx := context.Background()
+
+	if _, err := st.AddTeam(ctx, "backend", model.DefaultRequiredReviewers); err != nil {
+		t.Fatalf("AddTeam: %v", err)
+	}
+	_, err := st.AddUpdateUsers(ctx, []model.User{
+		{Id: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{Id: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	})
+	if err != nil {
+		t.Fatalf("AddUpdateUsers: %v", err)
+	}
+	createdAt := time.Now().UTC()
+	_, err = st.CreatePullRequestWithAssignments(ctx, &model.PullRequest{
+		Id:                "pr1",
+		Name:              "Add login",
+		AuthorID:          "u1",
+		Status:            model.PullRequestStatusOPEN,
+		AssignedReviewers: []string{},
+		CreatedAt:         &createdAt,
+	})
+	if err != nil {
+		t.Fatalf("CreatePullRequestWithAssignments: %v", err)
+	}
+
+	reviewerID, err := st.AddReviewAssignment(ctx, "pr1", "u2")
+	if err != nil {
+		t.Fatalf("AddReviewAssignment: %v", err)
+	}
+	if reviewerID != "u2" {
+		t.Fatalf("want reviewer u2, got %q", reviewerID)
+	}
+
+	_, err = st.AddReviewAssignment(ctx, "pr1", "u2")
+	var existsErr errs.ReviewAssignmentExistsError
+	if !errors.As(err, &existsErr) {
+		t.Fatalf("duplicate assignment: want ReviewAssignmentExistsError, got %v", err)
+	}
+	if existsErr.PullRequestID != "pr1" || existsErr.UserID != "u2" {
+		t.Fatalf("unexpected error %+v", existsErr)
+	}
+
+	if _, err := st.AddReviewAssignment(ctx, "pr1", "missing"); !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("missing user: want NotFoundErr, got %v", err)
+	}
+	if _, err := st.AddReviewAssignment(ctx, "missing", "u2"); !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("missing pull request: want NotFoundErr, got %v", err)
+	}
+
+	pr, err := st.GetPullRequest(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u2" {
+		t.Fatalf("want reviewers [u2], got %v", pr.AssignedReviewers)
+	}
+}
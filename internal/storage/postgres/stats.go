@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage/postgres/dao"
+)
+
+// GetUserStats counts current review assignments of every user.
+func (s *Storage) GetUserStats(ctx context.Context) ([]model.UserStats, error) {
+	q := `SELECT u.id AS user_id, u.username, u.team_name,
+			  COUNT(pr.id) AS total_assignments,
+			  COUNT(pr.id) FILTER (WHERE pr.status = 'OPEN') AS open_assignments,
+			  COUNT(pr.id) FILTER (WHERE pr.status = 'MERGED') AS merged_assignments,
+			  (SELECT COUNT(*) FROM assignment_events e
+			   WHERE e.type = 'REASSIGNED' AND e.old_reviewer_id = u.id) AS reassigned_from
+		  FROM users u
+		  LEFT JOIN review_assignments ra ON ra.user_id = u.id
+		  LEFT JOIN pull_requests pr ON pr.id = ra.pull_request_id
+		  GROUP BY u.id
+		  ORDER BY u.id`
+	rows, err := s.getExecutor(ctx).Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute user stats query: %w", err)
+	}
+	defer rows.Close()
+
+	daoStats, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.UserStats])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	stats := make([]model.UserStats, len(daoStats))
+	for i, daoStat := range daoStats {
+		stats[i] = daoStat.ToModel()
+	}
+
+	return stats, nil
+}
+
+// GetTeamStats counts current review assignments of members of every team.
+func (s *Storage) GetTeamStats(ctx context.Context) ([]model.TeamStats, error) {
+	q := `SELECT t.name AS team_name,
+			  COUNT(pr.id) AS total_assignments,
+			  COUNT(pr.id) FILTER (WHERE pr.status = 'OPEN') AS open_assignments,
+			  COUNT(pr.id) FILTER (WHERE pr.status = 'MERGED') AS merged_assignments,
+			  (SELECT COUNT(*) FROM assignment_events e
+			   JOIN users old ON old.id = e.old_reviewer_id
+			   WHERE e.type = 'REASSIGNED' AND old.team_name = t.name) AS reassignments,
+			  (SELECT EXTRACT(EPOCH FROM AVG(p.merged_at - p.created_at))::FLOAT8 FROM pull_requests p
+			   JOIN users author ON author.id = p.author_id
+			   WHERE p.status = 'MERGED' AND author.team_name = t.name) AS average_time_to_merge_seconds
+		  FROM teams t
+		  LEFT JOIN users u ON u.team_name = t.name
+		  LEFT JOIN review_assignments ra ON ra.user_id = u.id
+		  LEFT JOIN pull_requests pr ON pr.id = ra.pull_request_id
+		  GROUP BY t.name
+		  ORDER BY t.name`
+	rows, err := s.getExecutor(ctx).Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute team stats query: %w", err)
+	}
+	defer rows.Close()
+
+	daoStats, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.TeamStats])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	stats := make([]model.TeamStats, len(daoStats))
+	for i, daoStat := range daoStats {
+		stats[i] = daoStat.ToModel()
+	}
+
+	return stats, nil
+}
+
+// GetReassignmentCounts counts reassignment events by reason.
+func (s *Storage) GetReassignmentCounts(ctx context.Context) (map[string]int, error) {
+	q := `SELECT reason, COUNT(*) FROM assignment_events WHERE type = 'REASSIGNED' GROUP BY reason`
+	rows, err := s.getExecutor(ctx).Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute reassignment counts query: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+
+	var (
+		reason string
+		count  int
+	)
+	_, err = pgx.ForEachRow(rows, []any{&reason, &count}, func() error {
+		counts[reason] = count
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to read reassignment counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetAverageTimeToMerge averages time between creation and merge of merged pull requests.
+func (s *Storage) GetAverageTimeToMerge(ctx context.Context) (*float64, error) {
+	q := `SELECT EXTRACT(EPOCH FROM AVG(merged_at - created_at))::FLOAT8 FROM pull_requests WHERE status = 'MERGED'`
+
+	var seconds *float64
+	if err := s.getExecutor(ctx).QueryRow(ctx, q).Scan(&seconds); err != nil {
+		return nil, fmt.Errorf("postgres failed to execute average time to merge query: %w", err)
+	}
+
+	return seconds, nil
+}
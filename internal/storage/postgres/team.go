@@ -14,20 +14,30 @@ import (
 )
 
 // AddTeam inserts a new team into the database.
-func (s *Storage) AddTeam(ctx context.Context, name string) (string, error) {
-	q := `INSERT INTO teams (name) VALUES ($1)`
+func (s *Storage) AddTeam(ctx context.Context, name string, requiredReviewers int) (*model.Team, error) {
+	q := `INSERT INTO teams (name, required_reviewers) VALUES ($1, $2) RETURNING *`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, name, requiredReviewers)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute insert query for team: %w", err)
+	}
+	defer rows.Close()
 
-	var insertedName string
-	err := s.getExecutor(ctx).QueryRow(ctx, q, name).Scan(&insertedName)
+	daoTeam, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dao.Team])
 	if err != nil {
 		var pgxError *pgconn.PgError
 		if errors.As(err, &pgxError) && pgxError.Code == UniqueViolationErr {
-			return "", errs.TeamExistsError{TeamName: name}
+			return nil, errs.TeamExistsError{TeamName: name}
 		}
-		return "", fmt.Errorf("postgres failed to execute insert query for team: %w", err)
+		return nil, fmt.Errorf("pgx failed to collect team row: %w", err)
 	}
 
-	return insertedName, nil
+	return &model.Team{
+		Name:                  daoTeam.Name,
+		RequiredReviewers:     daoTeam.RequiredReviewers,
+		Members:               []model.TeamMember{},
+		ReviewSLASeconds:      daoTeam.ReviewSLASeconds,
+		ReviewSLAAutoReassign: daoTeam.ReviewSLAAutoReassign,
+	}, nil
 }
 
 // GetTeam retrieves a team by name, including all its members.
@@ -36,9 +46,10 @@ func (s *Storage) GetTeam(ctx context.Context, name string) (*model.Team, error)
 	err := s.InTransaction(ctx, func(ctx context.Context) error {
 		e := s.getExecutor(ctx)
 
-		qTeam := `SELECT name FROM teams WHERE name = $1`
-		var teamName string
-		err := e.QueryRow(ctx, qTeam, name).Scan(&teamName)
+		qTeam := `SELECT name, required_reviewers, review_sla_seconds, review_sla_auto_reassign FROM teams WHERE name = $1`
+		var daoTeam dao.Team
+		err := e.QueryRow(ctx, qTeam, name).
+			Scan(&daoTeam.Name, &daoTeam.RequiredReviewers, &daoTeam.ReviewSLASeconds, &daoTeam.ReviewSLAAutoReassign)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return errs.NotFoundErr
@@ -46,8 +57,8 @@ func (s *Storage) GetTeam(ctx context.Context, name string) (*model.Team, error)
 			return fmt.Errorf("postgres failed to query team: %w", err)
 		}
 
-		qMembers := `SELECT id, username, is_active FROM users WHERE team_name = $1`
-		rows, err := e.Query(ctx, qMembers, teamName)
+		qMembers := `SELECT id, username, is_active, chat_handle, email FROM users WHERE team_name = $1`
+		rows, err := e.Query(ctx, qMembers, daoTeam.Name)
 		if err != nil {
 			return fmt.Errorf("postgres failed to query team members: %w", err)
 		}
@@ -64,8 +75,11 @@ func (s *Storage) GetTeam(ctx context.Context, name string) (*model.Team, error)
 		}
 
 		team = model.Team{
-			Name:    teamName,
-			Members: members,
+			Name:                  daoTeam.Name,
+			RequiredReviewers:     daoTeam.RequiredReviewers,
+			Members:               members,
+			ReviewSLASeconds:      daoTeam.ReviewSLASeconds,
+			ReviewSLAAutoReassign: daoTeam.ReviewSLAAutoReassign,
 		}
 
 		return nil
@@ -76,3 +90,90 @@ func (s *Storage) GetTeam(ctx context.Context, name string) (*model.Team, error)
 	}
 	return &team, nil
 }
+
+// SetTeamRequiredReviewers updates number of reviewers required for pull requests of team members.
+func (s *Storage) SetTeamRequiredReviewers(ctx context.Context, name string, requiredReviewers int) error {
+	q := `UPDATE teams SET required_reviewers = $2 WHERE name = $1`
+	tag, err := s.getExecutor(ctx).Exec(ctx, q, name, requiredReviewers)
+	if err != nil {
+		return fmt.Errorf("postgres failed to execute update team query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.NotFoundErr
+	}
+	return nil
+}
+
+// SetTeamReviewSLA updates review SLA of team.
+func (s *Storage) SetTeamReviewSLA(ctx context.Context, name string, slaSeconds int, autoReassign bool) error {
+	q := `UPDATE teams SET review_sla_seconds = $2, review_sla_auto_reassign = $3 WHERE name = $1`
+	tag, err := s.getExecutor(ctx).Exec(ctx, q, name, slaSeconds, autoReassign)
+	if err != nil {
+		return fmt.Errorf("postgres failed to execute update team review sla query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.NotFoundErr
+	}
+	return nil
+}
+
+// GetTeamLeads returns ids of leads of team ordered by id.
+func (s *Storage) GetTeamLeads(ctx context.Context, teamName string) ([]string, error) {
+	q := `SELECT user_id FROM team_leads WHERE team_name = $1 ORDER BY user_id`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute get team leads query: %w", err)
+	}
+	defer rows.Close()
+
+	leads, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	return leads, nil
+}
+
+// SetTeamLeads replaces leads of team.
+func (s *Storage) SetTeamLeads(ctx context.Context, teamName string, userIDs []string) error {
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		e := s.getExecutor(ctx)
+
+		var exists bool
+		if err := e.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM teams WHERE name = $1)`, teamName).Scan(&exists); err != nil {
+			return fmt.Errorf("postgres failed to query team: %w", err)
+		}
+		if !exists {
+			return errs.NotFoundErr
+		}
+
+		if _, err := e.Exec(ctx, `DELETE FROM team_leads WHERE team_name = $1`, teamName); err != nil {
+			return fmt.Errorf("postgres failed to delete team leads: %w", err)
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+
+		builder := squirrelBuilder.Insert("team_leads").
+			Columns("team_name", "user_id").
+			Suffix("ON CONFLICT DO NOTHING")
+		for _, id := range userIDs {
+			builder = builder.Values(teamName, id)
+		}
+
+		q, vals, err := builder.ToSql()
+		if err != nil {
+			return fmt.Errorf("squirrel failed to build query: %w", err)
+		}
+
+		if _, err := e.Exec(ctx, q, vals...); err != nil {
+			var pgxError *pgconn.PgError
+			if errors.As(err, &pgxError) && pgxError.Code == ForeignKeyViolationErr {
+				return errs.NotFoundErr
+			}
+			return fmt.Errorf("postgres failed to execute insert team leads query: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage/postgres/dao"
+)
+
+const userTokenColumns = `id, user_id, name, created_at, revoked_at`
+
+// AddUserToken stores hash of user api token.
+func (s *Storage) AddUserToken(ctx context.Context, token *model.UserToken, tokenHash string) (*model.UserToken, error) {
+	q := `INSERT INTO user_tokens (user_id, name, token_hash, created_at)
+		  VALUES ($1, $2, $3, $4) RETURNING ` + userTokenColumns
+	rows, err := s.getExecutor(ctx).Query(ctx, q, token.UserID, token.Name, tokenHash, token.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute insert user token query: %w", err)
+	}
+	defer rows.Close()
+
+	daoToken, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dao.UserToken])
+	if err != nil {
+		var pgxError *pgconn.PgError
+		if errors.As(err, &pgxError) && pgxError.Code == ForeignKeyViolationErr {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("pgx failed to collect one row: %w", err)
+	}
+
+	created := daoToken.ToModel()
+
+	return &created, nil
+}
+
+// GetUserTokens retrieves all tokens of user including revoked ones.
+func (s *Storage) GetUserTokens(ctx context.Context, userID string) ([]model.UserToken, error) {
+	q := `SELECT ` + userTokenColumns + ` FROM user_tokens WHERE user_id = $1 ORDER BY id`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute get user tokens query: %w", err)
+	}
+	defer rows.Close()
+
+	daoTokens, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.UserToken])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	tokens := make([]model.UserToken, len(daoTokens))
+	for i, daoToken := range daoTokens {
+		tokens[i] = daoToken.ToModel()
+	}
+
+	return tokens, nil
+}
+
+// RevokeUserToken marks token as revoked. Revocation time of already revoked token is kept.
+func (s *Storage) RevokeUserToken(ctx context.Context, id int64, revokedAt time.Time) (*model.UserToken, error) {
+	q := `UPDATE user_tokens SET revoked_at = COALESCE(revoked_at, $2)
+		  WHERE id = $1 RETURNING ` + userTokenColumns
+	rows, err := s.getExecutor(ctx).Query(ctx, q, id, revokedAt)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute revoke user token query: %w", err)
+	}
+	defer rows.Close()
+
+	daoToken, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dao.UserToken])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("pgx failed to collect one row: %w", err)
+	}
+
+	revoked := daoToken.ToModel()
+
+	return &revoked, nil
+}
+
+// GetUserByTokenHash finds owner of not revoked token.
+func (s *Storage) GetUserByTokenHash(ctx context.Context, tokenHash string) (*model.User, error) {
+	q := `SELECT u.* FROM users u
+		  JOIN user_tokens t ON t.user_id = u.id
+		  WHERE t.token_hash = $1 AND t.revoked_at IS NULL`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	daoUser, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dao.User])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("pgx failed to collect one row: %w", err)
+	}
+
+	user := daoUser.ToModel()
+
+	return &user, nil
+}
@@ -13,19 +13,26 @@ import (
 )
 
 // AddUpdateUsers handles bulk insertion and updating of users using ON CONFLICT.
+// Empty chat handle or email of existing user keeps the stored one.
 func (s *Storage) AddUpdateUsers(ctx context.Context, users []model.User) ([]model.User, error) {
+	if len(users) == 0 {
+		return []model.User{}, nil
+	}
+
 	vals := make([]any, 0, len(users))
 	for _, user := range users {
-		vals = append(vals, []any{user.Id, user.Username, user.TeamName, user.IsActive})
+		vals = append(vals, []any{user.Id, user.Username, user.TeamName, user.IsActive, user.ChatHandle, user.Email})
 	}
 
 	builder := squirrelBuilder.Insert("users").
-		Columns("id", "username", "team_name", "is_active").
+		Columns("id", "username", "team_name", "is_active", "chat_handle", "email").
 		Values(vals...).
 		Suffix(`ON CONFLICT (id) DO UPDATE SET 
             username = EXCLUDED.username,
             team_name = EXCLUDED.team_name,
-            is_active = EXCLUDED.is_active
+            is_active = EXCLUDED.is_active,
+            chat_handle = COALESCE(NULLIF(EXCLUDED.chat_handle, ''), users.chat_handle),
+            email = COALESCE(NULLIF(EXCLUDED.email, ''), users.email)
 			RETURNING *`)
 
 	query, vals, err := builder.ToSql()
@@ -74,6 +81,50 @@ func (s *Storage) SetUserActivity(ctx context.Context, id string, active bool) (
 	return &user, nil
 }
 
+// SetTeamActivity updates the is_active status for all members of a team.
+func (s *Storage) SetTeamActivity(ctx context.Context, teamName string, active bool) ([]model.User, error) {
+	q := `UPDATE users SET is_active = $1 WHERE team_name = $2 RETURNING *`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, active, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	daoUsers, err := pgx.CollectRows(rows, pgx.RowToStructByName[dao.User])
+	if err != nil {
+		return nil, fmt.Errorf("pgx failed to collect rows: %w", err)
+	}
+
+	result := make([]model.User, len(daoUsers))
+	for i, daoUser := range daoUsers {
+		result[i] = daoUser.ToModel()
+	}
+
+	return result, nil
+}
+
+// GetUser retrieves a single user by ID.
+func (s *Storage) GetUser(ctx context.Context, id string) (*model.User, error) {
+	q := `SELECT * FROM users WHERE id = $1`
+	rows, err := s.getExecutor(ctx).Query(ctx, q, id)
+	if err != nil {
+		return nil, fmt.Errorf("postgres failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	daoUser, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dao.User])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("pgx failed to collect one row: %w", err)
+	}
+
+	user := daoUser.ToModel()
+
+	return &user, nil
+}
+
 // GetActiveColleges finds IDs of all active users belonging to the same team as the given userID (excluding userID itself).
 func (s *Storage) GetActiveColleges(ctx context.Context, userID string) ([]string, error) {
 	q := `SELECT id FROM users 
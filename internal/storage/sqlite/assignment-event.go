@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"review-assigner/internal/model"
+)
+
+// AddAssignmentEvents appends events to review assignment history.
+func (s *Storage) AddAssignmentEvents(ctx context.Context, events []model.AssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	builder := squirrelBuilder.Insert("assignment_events").
+		Columns("pull_request_id", "type", "actor", "old_reviewer_id", "new_reviewer_id", "reason", "created_at")
+	for _, e := range events {
+		builder = builder.Values(e.PullRequestID, e.Type, e.Actor, nullIfEmpty(e.OldReviewerID), nullIfEmpty(e.NewReviewerID), e.Reason, e.CreatedAt)
+	}
+
+	q, vals, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("squirrel failed to build query: %w", err)
+	}
+
+	if _, err = s.getExecutor(ctx).ExecContext(ctx, q, vals...); err != nil {
+		return fmt.Errorf("sqlite failed to execute insert assignment events query: %w", err)
+	}
+	return nil
+}
+
+// GetAssignmentEvents retrieves review assignment history of pull request.
+func (s *Storage) GetAssignmentEvents(ctx context.Context, prID string) ([]model.AssignmentEvent, error) {
+	q := `SELECT id, pull_request_id, type, actor, old_reviewer_id, new_reviewer_id, reason, created_at
+		  FROM assignment_events WHERE pull_request_id = ? ORDER BY id`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, prID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute get assignment events query: %w", err)
+	}
+	defer rows.Close()
+
+	events := []model.AssignmentEvent{}
+	for rows.Next() {
+		var (
+			event                        model.AssignmentEvent
+			oldReviewerID, newReviewerID *string
+		)
+		err := rows.Scan(&event.ID, &event.PullRequestID, &event.Type, &event.Actor,
+			&oldReviewerID, &newReviewerID, &event.Reason, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan assignment event row: %w", err)
+		}
+		if oldReviewerID != nil {
+			event.OldReviewerID = *oldReviewerID
+		}
+		if newReviewerID != nil {
+			event.NewReviewerID = *newReviewerID
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read assignment event rows: %w", err)
+	}
+
+	return events, nil
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// SetDigestOptOut unsubscribes user from digests or subscribes back.
+func (s *Storage) SetDigestOptOut(ctx context.Context, userID string, optOut bool) error {
+	q := `INSERT INTO user_digests (user_id, opt_out) VALUES (?, ?)
+		  ON CONFLICT (user_id) DO UPDATE SET opt_out = excluded.opt_out`
+	if _, err := s.getExecutor(ctx).ExecContext(ctx, q, userID, optOut); err != nil {
+		if errorCode(err) == ForeignKeyViolationErr {
+			return errs.NotFoundErr
+		}
+		return fmt.Errorf("sqlite failed to execute upsert digest opt out query: %w", err)
+	}
+	return nil
+}
+
+// GetDigestRecipients retrieves active users with email who haven't opted out of digests.
+func (s *Storage) GetDigestRecipients(ctx context.Context) ([]model.User, error) {
+	q := `SELECT u.id, u.username, u.team_name, u.is_active, u.chat_handle, u.email FROM users u
+		  LEFT JOIN user_digests d ON d.user_id = u.id
+		  WHERE u.is_active AND u.email <> '' AND NOT COALESCE(d.opt_out, FALSE)
+		  ORDER BY u.id`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute get digest recipients query: %w", err)
+	}
+
+	return collectUsers(rows)
+}
+
+// ClaimDigest sets time of last digest of user unless digest was already sent not before since.
+func (s *Storage) ClaimDigest(ctx context.Context, userID string, sentAt, since time.Time) (bool, error) {
+	// times are compared as text, so they are written in UTC
+	q := `INSERT INTO user_digests (user_id, last_sent_at) VALUES (?1, ?2)
+		  ON CONFLICT (user_id) DO UPDATE SET last_sent_at = excluded.last_sent_at
+		  WHERE user_digests.last_sent_at IS NULL OR user_digests.last_sent_at < ?3`
+	res, err := s.getExecutor(ctx).ExecContext(ctx, q, userID, sentAt.UTC(), since.UTC())
+	if err != nil {
+		if errorCode(err) == ForeignKeyViolationErr {
+			return false, errs.NotFoundErr
+		}
+		return false, fmt.Errorf("sqlite failed to execute claim digest query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("sqlite failed to get affected rows: %w", err)
+	}
+	return affected == 1, nil
+}
@@ -0,0 +1,29 @@
+package sqlite
+
+import (
+	"errors"
+
+	"modernc.org/sqlite"
+)
+
+// Extended result codes of constraint violations.
+const (
+	ForeignKeyViolationErr = 787
+	PrimaryKeyViolationErr = 1555
+	UniqueViolationErr     = 2067
+)
+
+// errorCode returns sqlite extended result code of err, or 0 if err is not sqlite error.
+func errorCode(err error) int {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code()
+	}
+	return 0
+}
+
+// isUniqueViolation reports whether err violates primary key or unique constraint.
+func isUniqueViolation(err error) bool {
+	code := errorCode(err)
+	return code == PrimaryKeyViolationErr || code == UniqueViolationErr
+}
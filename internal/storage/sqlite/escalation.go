@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"review-assigner/internal/errs"
+)
+
+// GetStalePullRequestIDs retrieves ids of open pull requests which exceeded review SLA of author's team
+// and are not escalated yet, oldest first.
+func (s *Storage) GetStalePullRequestIDs(ctx context.Context, now time.Time) ([]string, error) {
+	// creation times may be written in any time zone, so they are compared as unix time
+	q := `SELECT pr.id FROM pull_requests pr
+		  JOIN users u ON u.id = pr.author_id
+		  JOIN teams t ON t.name = u.team_name
+		  LEFT JOIN pull_request_escalations e ON e.pull_request_id = pr.id
+		  WHERE pr.status = 'OPEN' AND t.review_sla_seconds > 0 AND e.pull_request_id IS NULL
+			AND unixepoch(pr.created_at, 'subsec') + t.review_sla_seconds <= ?
+		  ORDER BY pr.created_at, pr.id`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, float64(now.UnixMicro())/1e6)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute get stale pull requests query: %w", err)
+	}
+
+	return collectStrings(rows)
+}
+
+// AddPullRequestEscalation records escalation of pull request unless it is already escalated.
+func (s *Storage) AddPullRequestEscalation(ctx context.Context, prID string, escalatedAt time.Time) (bool, error) {
+	q := `INSERT INTO pull_request_escalations (pull_request_id, escalated_at) VALUES (?, ?)
+		  ON CONFLICT (pull_request_id) DO NOTHING`
+	res, err := s.getExecutor(ctx).ExecContext(ctx, q, prID, escalatedAt.UTC())
+	if err != nil {
+		if errorCode(err) == ForeignKeyViolationErr {
+			return false, errs.NotFoundErr
+		}
+		return false, fmt.Errorf("sqlite failed to execute insert pull request escalation query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("sqlite failed to get affected rows: %w", err)
+	}
+	return affected == 1, nil
+}
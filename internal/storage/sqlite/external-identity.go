@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// SetExternalIdentity maps login to user, replacing previous mapping of login.
+func (s *Storage) SetExternalIdentity(ctx context.Context, identity model.ExternalIdentity) error {
+	q := `INSERT INTO external_identities (provider, login, user_id) VALUES (?, ?, ?)
+		  ON CONFLICT (provider, login) DO UPDATE SET user_id = excluded.user_id`
+	if _, err := s.getExecutor(ctx).ExecContext(ctx, q, identity.Provider, identity.Login, identity.UserID); err != nil {
+		if errorCode(err) == ForeignKeyViolationErr {
+			return errs.NotFoundErr
+		}
+		return fmt.Errorf("sqlite failed to execute upsert external identity query: %w", err)
+	}
+	return nil
+}
+
+// GetExternalIdentities retrieves identities of user.
+func (s *Storage) GetExternalIdentities(ctx context.Context, userID string) ([]model.ExternalIdentity, error) {
+	q := `SELECT provider, login, user_id FROM external_identities WHERE user_id = ? ORDER BY provider, login`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute get external identities query: %w", err)
+	}
+	defer rows.Close()
+
+	identities := []model.ExternalIdentity{}
+	for rows.Next() {
+		var identity model.ExternalIdentity
+		if err := rows.Scan(&identity.Provider, &identity.Login, &identity.UserID); err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan external identity row: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read external identity rows: %w", err)
+	}
+
+	return identities, nil
+}
+
+// DeleteExternalIdentity removes mapping of login.
+func (s *Storage) DeleteExternalIdentity(ctx context.Context, provider, login string) error {
+	q := `DELETE FROM external_identities WHERE provider = ? AND login = ?`
+	res, err := s.getExecutor(ctx).ExecContext(ctx, q, provider, login)
+	if err != nil {
+		return fmt.Errorf("sqlite failed to execute delete external identity query: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlite failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errs.NotFoundErr
+	}
+	return nil
+}
+
+// GetUserByExternalIdentity finds user mapped to login.
+func (s *Storage) GetUserByExternalIdentity(ctx context.Context, provider, login string) (*model.User, error) {
+	q := `SELECT u.id, u.username, u.team_name, u.is_active, u.chat_handle, u.email FROM users u
+		  JOIN external_identities i ON i.user_id = u.id
+		  WHERE i.provider = ? AND i.login = ?`
+	user, err := scanUser(s.getExecutor(ctx).QueryRowContext(ctx, q, provider, login))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("sqlite failed to execute query: %w", err)
+	}
+	return &user, nil
+}
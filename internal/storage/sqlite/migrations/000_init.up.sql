@@ -0,0 +1,64 @@
+CREATE TABLE IF NOT EXISTS teams
+(
+    name               TEXT PRIMARY KEY,
+    required_reviewers INTEGER NOT NULL DEFAULT 2 CHECK (required_reviewers > 0)
+);
+
+CREATE TABLE IF NOT EXISTS users
+(
+    id        TEXT PRIMARY KEY,
+    username  TEXT    NOT NULL,
+    team_name TEXT    NOT NULL REFERENCES teams (name),
+    is_active BOOLEAN NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS pull_requests
+(
+    id                  TEXT PRIMARY KEY,
+    name                TEXT      NOT NULL,
+    author_id           TEXT      NOT NULL REFERENCES users (id),
+    status              TEXT      NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'MERGED')),
+    created_at          TIMESTAMP NOT NULL,
+    merged_at           TIMESTAMP,
+    need_more_reviewers BOOLEAN   NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE IF NOT EXISTS review_assignments
+(
+    user_id         TEXT REFERENCES users (id),
+    pull_request_id TEXT REFERENCES pull_requests (id),
+
+    PRIMARY KEY (user_id, pull_request_id)
+);
+
+CREATE TABLE IF NOT EXISTS assignment_events
+(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    pull_request_id TEXT      NOT NULL REFERENCES pull_requests (id),
+    type            TEXT      NOT NULL CHECK (type IN ('ASSIGNED', 'REASSIGNED', 'UNASSIGNED', 'MERGED')),
+    actor           TEXT      NOT NULL,
+    old_reviewer_id TEXT REFERENCES users (id),
+    new_reviewer_id TEXT REFERENCES users (id),
+    reason          TEXT      NOT NULL,
+    created_at      TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_team_active ON users (team_name, is_active);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author ON pull_requests (author_id);
+CREATE INDEX IF NOT EXISTS idx_review_assignments_pull_request ON review_assignments (pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_assignment_events_pull_request ON assignment_events (pull_request_id, id);
+
+-- assignment_events is append-only
+CREATE TRIGGER IF NOT EXISTS assignment_events_no_update
+    BEFORE UPDATE
+    ON assignment_events
+BEGIN
+    SELECT RAISE(ABORT, 'assignment_events is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS assignment_events_no_delete
+    BEFORE DELETE
+    ON assignment_events
+BEGIN
+    SELECT RAISE(ABORT, 'assignment_events is append-only');
+END;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_tokens
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id    TEXT      NOT NULL REFERENCES users (id),
+    name       TEXT      NOT NULL,
+    token_hash TEXT      NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user ON user_tokens (user_id);
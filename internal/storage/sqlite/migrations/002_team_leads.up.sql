@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS team_leads
+(
+    team_name TEXT REFERENCES teams (name),
+    user_id   TEXT REFERENCES users (id),
+
+    PRIMARY KEY (team_name, user_id)
+);
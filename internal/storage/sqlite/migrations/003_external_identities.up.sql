@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS external_identities
+(
+    provider TEXT NOT NULL,
+    login    TEXT NOT NULL,
+    user_id  TEXT NOT NULL REFERENCES users (id),
+
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_identities_user ON external_identities (user_id);
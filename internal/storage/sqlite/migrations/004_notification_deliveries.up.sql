@@ -0,0 +1,2 @@
+-- notification deliveries were folded into 005_outbox. File is kept empty,
+-- as applied migrations are counted in user_version and later ones must keep their numbers.
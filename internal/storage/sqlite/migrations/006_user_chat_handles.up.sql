@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN chat_handle TEXT NOT NULL DEFAULT '';
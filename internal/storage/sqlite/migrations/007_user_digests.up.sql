@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN email TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS user_digests
+(
+    user_id      TEXT PRIMARY KEY REFERENCES users (id),
+    opt_out      BOOLEAN NOT NULL DEFAULT FALSE,
+    last_sent_at TIMESTAMP
+);
@@ -0,0 +1,48 @@
+ALTER TABLE teams
+    ADD COLUMN review_sla_seconds INTEGER NOT NULL DEFAULT 0 CHECK (review_sla_seconds >= 0);
+ALTER TABLE teams
+    ADD COLUMN review_sla_auto_reassign BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS pull_request_escalations
+(
+    pull_request_id TEXT PRIMARY KEY REFERENCES pull_requests (id),
+    escalated_at    TIMESTAMP NOT NULL
+);
+
+-- check constraint can't be altered, so assignment_events is rebuilt to allow ESCALATED type
+CREATE TABLE assignment_events_new
+(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    pull_request_id TEXT      NOT NULL REFERENCES pull_requests (id),
+    type            TEXT      NOT NULL CHECK (type IN ('ASSIGNED', 'REASSIGNED', 'UNASSIGNED', 'MERGED', 'ESCALATED')),
+    actor           TEXT      NOT NULL,
+    old_reviewer_id TEXT REFERENCES users (id),
+    new_reviewer_id TEXT REFERENCES users (id),
+    reason          TEXT      NOT NULL,
+    created_at      TIMESTAMP NOT NULL
+);
+
+INSERT INTO assignment_events_new (id, pull_request_id, type, actor, old_reviewer_id, new_reviewer_id, reason, created_at)
+SELECT id, pull_request_id, type, actor, old_reviewer_id, new_reviewer_id, reason, created_at
+FROM assignment_events
+ORDER BY id;
+
+DROP TABLE assignment_events;
+ALTER TABLE assignment_events_new RENAME TO assignment_events;
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_pull_request ON assignment_events (pull_request_id, id);
+
+-- assignment_events is append-only
+CREATE TRIGGER IF NOT EXISTS assignment_events_no_update
+    BEFORE UPDATE
+    ON assignment_events
+BEGIN
+    SELECT RAISE(ABORT, 'assignment_events is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS assignment_events_no_delete
+    BEFORE DELETE
+    ON assignment_events
+BEGIN
+    SELECT RAISE(ABORT, 'assignment_events is append-only');
+END;
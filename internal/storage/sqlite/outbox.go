@@ -0,0 +1,99 @@
+package sqlite
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+const outboxColumns = `id, sink, type, payload, status, attempts, next_attempt_at, last_error, created_at, processed_at`
+
+// AddOutboxMessages adds messages to outbox.
+func (s *Storage) AddOutboxMessages(ctx context.Context, messages []model.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	builder := squirrelBuilder.Insert("outbox").
+		Columns("sink", "type", "payload", "status", "attempts", "next_attempt_at", "last_error", "created_at")
+	for _, m := range messages {
+		// times are compared as text, so they are kept in UTC
+		builder = builder.Values(m.Sink, m.Type, string(m.Payload), m.Status, m.Attempts, m.NextAttemptAt.UTC(), m.LastError, m.CreatedAt)
+	}
+
+	q, vals, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("squirrel failed to build query: %w", err)
+	}
+
+	if _, err = s.getExecutor(ctx).ExecContext(ctx, q, vals...); err != nil {
+		return fmt.Errorf("sqlite failed to execute insert outbox messages query: %w", err)
+	}
+	return nil
+}
+
+// ClaimOutboxMessages leases due messages. SQLite has single writer, so claims never overlap.
+func (s *Storage) ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxMessage, error) {
+	q := `UPDATE outbox SET next_attempt_at = ?
+		  WHERE id IN (
+		      SELECT id FROM outbox
+		      WHERE status = 'PENDING' AND next_attempt_at <= ?
+		      ORDER BY next_attempt_at, id LIMIT ?
+		  )
+		  RETURNING ` + outboxColumns
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, now.Add(lease).UTC(), now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute claim outbox messages query: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []model.OutboxMessage{}
+	for rows.Next() {
+		var (
+			m       model.OutboxMessage
+			payload string
+		)
+		err := rows.Scan(&m.ID, &m.Sink, &m.Type, &payload, &m.Status, &m.Attempts,
+			&m.NextAttemptAt, &m.LastError, &m.CreatedAt, &m.ProcessedAt)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan outbox message row: %w", err)
+		}
+		m.Payload = []byte(payload)
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read outbox message rows: %w", err)
+	}
+
+	// RETURNING doesn't keep order of rows
+	slices.SortFunc(messages, func(a, b model.OutboxMessage) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return messages, nil
+}
+
+// UpdateOutboxMessage saves outcome of processing attempt.
+func (s *Storage) UpdateOutboxMessage(ctx context.Context, message *model.OutboxMessage) error {
+	q := `UPDATE outbox
+		  SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, processed_at = ?
+		  WHERE id = ?`
+	res, err := s.getExecutor(ctx).ExecContext(ctx, q, message.Status, message.Attempts,
+		message.NextAttemptAt.UTC(), message.LastError, message.ProcessedAt, message.ID)
+	if err != nil {
+		return fmt.Errorf("sqlite failed to execute update outbox message query: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlite failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errs.NotFoundErr
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+const pullRequestColumns = `id, name, author_id, status, created_at, merged_at, need_more_reviewers`
+
+// CreatePullRequestWithAssignments creates pull request and assigns users to it.
+func (s *Storage) CreatePullRequestWithAssignments(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	var createdPR model.PullRequest
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		e := s.getExecutor(ctx)
+
+		qPR := `INSERT INTO pull_requests (` + pullRequestColumns + `)
+		  VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING ` + pullRequestColumns
+		row := e.QueryRowContext(ctx, qPR, pr.Id, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt, pr.NeedMoreReviewers)
+		var err error
+		createdPR, err = scanPullRequest(row)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return errs.PullRequestExistsError{PullRequestID: pr.Id}
+			}
+			return fmt.Errorf("sqlite failed to execute insert query for pull request: %w", err)
+		}
+
+		createdPR.AssignedReviewers = make([]string, 0, len(pr.AssignedReviewers))
+
+		if len(pr.AssignedReviewers) > 0 {
+			builder := squirrelBuilder.Insert("review_assignments").
+				Columns("user_id", "pull_request_id").
+				Suffix("RETURNING user_id")
+			for _, reviewer := range pr.AssignedReviewers {
+				builder = builder.Values(reviewer, pr.Id)
+			}
+
+			qAssignments, vals, err := builder.ToSql()
+			if err != nil {
+				return fmt.Errorf("squirrel failed to build query: %w", err)
+			}
+
+			rows, err := e.QueryContext(ctx, qAssignments, vals...)
+			if err != nil {
+				return fmt.Errorf("sqlite failed to execute insert query for review assignments: %w", err)
+			}
+
+			createdPR.AssignedReviewers, err = collectStrings(rows)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return &createdPR, nil
+}
+
+func (s *Storage) GetPullRequest(ctx context.Context, id string) (*model.PullRequest, error) {
+	var result model.PullRequest
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		qPR := `SELECT ` + pullRequestColumns + ` FROM pull_requests WHERE id = ?`
+		var err error
+		result, err = scanPullRequest(s.getExecutor(ctx).QueryRowContext(ctx, qPR, id))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errs.NotFoundErr
+			}
+			return fmt.Errorf("sqlite failed to get pull request: %w", err)
+		}
+
+		reviewers, err := s.getReviewers(ctx, []string{id})
+		if err != nil {
+			return err
+		}
+		result.AssignedReviewers = reviewers[id]
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Storage) UpdatePullRequest(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	q := `UPDATE pull_requests
+		  SET name = ?2, author_id = ?3, status = ?4, created_at = ?5, merged_at = ?6, need_more_reviewers = ?7
+		  WHERE id = ?1 RETURNING ` + pullRequestColumns
+	row := s.getExecutor(ctx).QueryRowContext(ctx, q, pr.Id, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt, pr.MergedAt, pr.NeedMoreReviewers)
+	updatedPR, err := scanPullRequest(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("sqlite failed to execute update pull request query: %w", err)
+	}
+
+	updatedPR.AssignedReviewers = pr.AssignedReviewers
+
+	return &updatedPR, nil
+}
+
+// GetUnderstaffedPullRequests finds open pull requests marked as needing more reviewers.
+func (s *Storage) GetUnderstaffedPullRequests(ctx context.Context, teamName string) ([]model.PullRequest, error) {
+	var result []model.PullRequest
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		qPRs := `SELECT pr.id, pr.name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.need_more_reviewers
+			  FROM pull_requests pr
+			  JOIN users u ON u.id = pr.author_id
+			  WHERE pr.status = 'OPEN' AND pr.need_more_reviewers AND (?1 = '' OR u.team_name = ?1)
+			  ORDER BY pr.created_at`
+		rows, err := s.getExecutor(ctx).QueryContext(ctx, qPRs, teamName)
+		if err != nil {
+			return fmt.Errorf("sqlite failed to execute understaffed pull requests query: %w", err)
+		}
+		defer rows.Close()
+
+		result = []model.PullRequest{}
+		for rows.Next() {
+			pr, err := scanPullRequest(rows)
+			if err != nil {
+				return fmt.Errorf("sqlite failed to scan pull request row: %w", err)
+			}
+			result = append(result, pr)
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("sqlite failed to read pull request rows: %w", err)
+		}
+		// release connection before querying reviewers
+		rows.Close()
+
+		ids := make([]string, len(result))
+		for i, pr := range result {
+			ids[i] = pr.Id
+		}
+
+		reviewers, err := s.getReviewers(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for i := range result {
+			result[i].AssignedReviewers = reviewers[result[i].Id]
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateNeedMoreReviewers recomputes need_more_reviewers of open pull requests of team authors.
+func (s *Storage) UpdateNeedMoreReviewers(ctx context.Context, teamName string, requiredReviewers int) error {
+	q := `UPDATE pull_requests
+		  SET need_more_reviewers = (SELECT count(*) FROM review_assignments ra WHERE ra.pull_request_id = pull_requests.id) < ?2
+		  WHERE status = 'OPEN' AND author_id IN (SELECT id FROM users WHERE team_name = ?1)`
+	if _, err := s.getExecutor(ctx).ExecContext(ctx, q, teamName, requiredReviewers); err != nil {
+		return fmt.Errorf("sqlite failed to execute update need more reviewers query: %w", err)
+	}
+	return nil
+}
+
+// getReviewers returns assigned reviewers of each of pull requests.
+func (s *Storage) getReviewers(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	reviewers := make(map[string][]string, len(prIDs))
+	for _, id := range prIDs {
+		reviewers[id] = []string{}
+	}
+	if len(prIDs) == 0 {
+		return reviewers, nil
+	}
+
+	q, vals, err := squirrelBuilder.Select("pull_request_id", "user_id").
+		From("review_assignments").
+		Where(squirrel.Eq{"pull_request_id": prIDs}).
+		OrderBy("rowid").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("squirrel failed to build query: %w", err)
+	}
+
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, vals...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to get review assignments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID, userID string
+		if err := rows.Scan(&prID, &userID); err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan review assignment row: %w", err)
+		}
+		reviewers[prID] = append(reviewers[prID], userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read review assignment rows: %w", err)
+	}
+
+	return reviewers, nil
+}
+
+func scanPullRequest(row scanner) (model.PullRequest, error) {
+	var pr model.PullRequest
+	err := row.Scan(&pr.Id, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.NeedMoreReviewers)
+	return pr, err
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+func (s *Storage) DeleteReviewAssignment(ctx context.Context, prID string, userID string) error {
+	q := `DELETE FROM review_assignments WHERE pull_request_id = ? AND user_id = ?`
+	_, err := s.getExecutor(ctx).ExecContext(ctx, q, prID, userID)
+	if err != nil {
+		return fmt.Errorf("sqlite failed to delete review assignment: %w", err)
+	}
+	return nil
+}
+
+// AddReviewAssignment assigns user to pull request as a reviewer.
+func (s *Storage) AddReviewAssignment(ctx context.Context, prID string, userID string) (reviewerID string, err error) {
+	q := `INSERT INTO review_assignments (user_id, pull_request_id) VALUES (?, ?) RETURNING user_id`
+	err = s.getExecutor(ctx).QueryRowContext(ctx, q, userID, prID).Scan(&reviewerID)
+	if err != nil {
+		switch code := errorCode(err); {
+		case code == PrimaryKeyViolationErr || code == UniqueViolationErr:
+			return "", errs.ReviewAssignmentExistsError{PullRequestID: prID, UserID: userID}
+		case code == ForeignKeyViolationErr:
+			return "", errs.NotFoundErr
+		}
+		return "", fmt.Errorf("sqlite failed to execute insert review assignment query: %w", err)
+	}
+	return reviewerID, nil
+}
+
+func (s *Storage) GetUserAssignments(ctx context.Context, userID string) ([]model.PullRequestShort, error) {
+	q := `SELECT id, name, author_id, status FROM pull_requests
+		  WHERE id IN
+		        (SELECT pull_request_id FROM review_assignments WHERE user_id = ?)`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute get user assignments query: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []model.PullRequestShort{}
+	for rows.Next() {
+		var pr model.PullRequestShort
+		if err := rows.Scan(&pr.Id, &pr.Name, &pr.AuthorID, &pr.Status); err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan pull request row: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read pull request rows: %w", err)
+	}
+
+	return prs, nil
+}
+
+// GetOpenAssignmentCounts counts review assignments on not merged pull requests for each of userIDs.
+func (s *Storage) GetOpenAssignmentCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		counts[id] = 0
+	}
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	q, vals, err := squirrelBuilder.Select("ra.user_id", "COUNT(*)").
+		From("review_assignments ra").
+		Join("pull_requests pr ON pr.id = ra.pull_request_id").
+		Where("pr.status <> 'MERGED'").
+		Where(squirrel.Eq{"ra.user_id": userIDs}).
+		GroupBy("ra.user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("squirrel failed to build query: %w", err)
+	}
+
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, vals...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute open assignment counts query: %w", err)
+	}
+
+	if err := collectCounts(rows, counts); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
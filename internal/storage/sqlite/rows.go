@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// collectStrings reads single string column of every row and closes rows.
+func collectStrings(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	result := []string{}
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan row: %w", err)
+		}
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// collectCounts reads (key, count) rows into counts and closes rows.
+func collectCounts(rows *sql.Rows, counts map[string]int) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			key   string
+			count int
+		)
+		if err := rows.Scan(&key, &count); err != nil {
+			return fmt.Errorf("sqlite failed to scan count row: %w", err)
+		}
+		counts[key] = count
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("sqlite failed to read count rows: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/storage"
+	"review-assigner/internal/storage/sqlite"
+	"review-assigner/internal/storage/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		cfg := config.SQLiteConfig{Path: filepath.Join(t.TempDir(), "review-assigner.db")}
+		st, err := sqlite.New(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("sqlite.New: %v", err)
+		}
+		t.Cleanup(st.Close)
+		return st
+	})
+}
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"review-assigner/internal/model"
+)
+
+// GetUserStats counts current review assignments of every user.
+func (s *Storage) GetUserStats(ctx context.Context) ([]model.UserStats, error) {
+	q := `SELECT u.id, u.username, u.team_name,
+			  COUNT(pr.id),
+			  COALESCE(SUM(CASE WHEN pr.status = 'OPEN' THEN 1 ELSE 0 END), 0),
+			  COALESCE(SUM(CASE WHEN pr.status = 'MERGED' THEN 1 ELSE 0 END), 0),
+			  (SELECT COUNT(*) FROM assignment_events e
+			   WHERE e.type = 'REASSIGNED' AND e.old_reviewer_id = u.id)
+		  FROM users u
+		  LEFT JOIN review_assignments ra ON ra.user_id = u.id
+		  LEFT JOIN pull_requests pr ON pr.id = ra.pull_request_id
+		  GROUP BY u.id
+		  ORDER BY u.id`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute user stats query: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []model.UserStats{}
+	for rows.Next() {
+		var st model.UserStats
+		err := rows.Scan(&st.UserID, &st.Username, &st.TeamName,
+			&st.TotalAssignments, &st.OpenAssignments, &st.MergedAssignments, &st.ReassignedFrom)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan user stats row: %w", err)
+		}
+		stats = append(stats, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read user stats rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetTeamStats counts current review assignments of members of every team.
+func (s *Storage) GetTeamStats(ctx context.Context) ([]model.TeamStats, error) {
+	q := `SELECT t.name,
+			  COUNT(pr.id),
+			  COALESCE(SUM(CASE WHEN pr.status = 'OPEN' THEN 1 ELSE 0 END), 0),
+			  COALESCE(SUM(CASE WHEN pr.status = 'MERGED' THEN 1 ELSE 0 END), 0),
+			  (SELECT COUNT(*) FROM assignment_events e
+			   JOIN users old ON old.id = e.old_reviewer_id
+			   WHERE e.type = 'REASSIGNED' AND old.team_name = t.name),
+			  (SELECT AVG(julianday(p.merged_at) - julianday(p.created_at)) * 86400.0 FROM pull_requests p
+			   JOIN users author ON author.id = p.author_id
+			   WHERE p.status = 'MERGED' AND author.team_name = t.name)
+		  FROM teams t
+		  LEFT JOIN users u ON u.team_name = t.name
+		  LEFT JOIN review_assignments ra ON ra.user_id = u.id
+		  LEFT JOIN pull_requests pr ON pr.id = ra.pull_request_id
+		  GROUP BY t.name
+		  ORDER BY t.name`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute team stats query: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []model.TeamStats{}
+	for rows.Next() {
+		var st model.TeamStats
+		err := rows.Scan(&st.TeamName, &st.TotalAssignments, &st.OpenAssignments, &st.MergedAssignments,
+			&st.Reassignments, &st.AverageTimeToMergeSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan team stats row: %w", err)
+		}
+		stats = append(stats, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read team stats rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetReassignmentCounts counts reassignment events by reason.
+func (s *Storage) GetReassignmentCounts(ctx context.Context) (map[string]int, error) {
+	q := `SELECT reason, COUNT(*) FROM assignment_events WHERE type = 'REASSIGNED' GROUP BY reason`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute reassignment counts query: %w", err)
+	}
+
+	counts := make(map[string]int)
+	if err := collectCounts(rows, counts); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// GetAverageTimeToMerge averages time between creation and merge of merged pull requests.
+func (s *Storage) GetAverageTimeToMerge(ctx context.Context) (*float64, error) {
+	q := `SELECT AVG(julianday(merged_at) - julianday(created_at)) * 86400.0 FROM pull_requests WHERE status = 'MERGED'`
+
+	var seconds *float64
+	if err := s.getExecutor(ctx).QueryRowContext(ctx, q).Scan(&seconds); err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute average time to merge query: %w", err)
+	}
+
+	return seconds, nil
+}
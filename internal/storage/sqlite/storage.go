@@ -0,0 +1,163 @@
+// Package sqlite implements storage.Storage on top of SQLite for single-node deployments.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"slices"
+
+	"github.com/Masterminds/squirrel"
+	_ "modernc.org/sqlite"
+
+	"review-assigner/internal/config"
+	"review-assigner/internal/storage"
+)
+
+var _ storage.Storage = (*Storage)(nil)
+
+var txContextKey activeTxKey = 0
+
+var squirrelBuilder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question)
+
+//go:embed migrations/*.up.sql
+var migrations embed.FS
+
+type Storage struct {
+	// db is not meant to be used in data access methods!
+	// use getExecutor instead.
+	db *sql.DB
+}
+
+type activeTxKey int
+
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// New opens sqlite database file and applies migrations, so storage is ready to work.
+func New(ctx context.Context, cfg config.SQLiteConfig) (*Storage, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_time_format", "sqlite")
+
+	db, err := sql.Open("sqlite", "file:"+cfg.Path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// sqlite allows single writer, so transactions are serialized on one connection
+	db.SetMaxOpenConns(1)
+
+	if err = db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	s := &Storage{db: db}
+	if err = s.migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	slog.Info("connected to sqlite database", "path", cfg.Path)
+
+	return s, nil
+}
+
+// Close must be called for graceful shutdown
+func (s *Storage) Close() {
+	if s.db != nil {
+		slog.Info("closing sqlite database...")
+		if err := s.db.Close(); err != nil {
+			slog.Error("failed to close sqlite database", "error", err)
+			return
+		}
+		slog.Info("sqlite database closed")
+	}
+}
+
+func (s *Storage) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sqlite failed to begin transaction: %w", err)
+	}
+
+	txCtx := context.WithValue(ctx, txContextKey, tx)
+
+	var commitErr error
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		} else if commitErr != nil {
+			_ = tx.Rollback()
+		} else {
+			if commitErr = tx.Commit(); commitErr != nil {
+				commitErr = fmt.Errorf("failed to commit transaction: %w", commitErr)
+			}
+		}
+	}()
+
+	commitErr = fn(txCtx)
+
+	return commitErr
+}
+
+// getExecutor retrieves the active transaction from the context if it exists,
+// otherwise, it returns the underlying database.
+func (s *Storage) getExecutor(ctx context.Context) executor {
+	if tx, ok := ctx.Value(txContextKey).(*sql.Tx); ok {
+		return tx
+	}
+	return s.db
+}
+
+// migrate applies embedded migrations which are not applied yet.
+// Number of applied migrations is tracked in user_version pragma.
+func (s *Storage) migrate(ctx context.Context) error {
+	names, err := fs.Glob(migrations, "migrations/*.up.sql")
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	slices.Sort(names)
+
+	var version int
+	if err := s.db.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&version); err != nil {
+		return fmt.Errorf("sqlite failed to get schema version: %w", err)
+	}
+
+	for i := version; i < len(names); i++ {
+		migration, err := migrations.ReadFile(names[i])
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", names[i], err)
+		}
+
+		err = s.InTransaction(ctx, func(ctx context.Context) error {
+			e := s.getExecutor(ctx)
+			if _, err := e.ExecContext(ctx, string(migration)); err != nil {
+				return fmt.Errorf("sqlite failed to apply migration %s: %w", names[i], err)
+			}
+			// pragma doesn't accept parameters
+			if _, err := e.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+				return fmt.Errorf("sqlite failed to set schema version: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		slog.Info("applied sqlite migration", "migration", names[i])
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+// AddTeam inserts a new team into the database.
+func (s *Storage) AddTeam(ctx context.Context, name string, requiredReviewers int) (*model.Team, error) {
+	q := `INSERT INTO teams (name, required_reviewers) VALUES (?, ?) RETURNING name, required_reviewers`
+
+	team := model.Team{Members: []model.TeamMember{}}
+	err := s.getExecutor(ctx).QueryRowContext(ctx, q, name, requiredReviewers).Scan(&team.Name, &team.RequiredReviewers)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, errs.TeamExistsError{TeamName: name}
+		}
+		return nil, fmt.Errorf("sqlite failed to execute insert query for team: %w", err)
+	}
+
+	return &team, nil
+}
+
+// GetTeam retrieves a team by name, including all its members.
+func (s *Storage) GetTeam(ctx context.Context, name string) (*model.Team, error) {
+	var team model.Team
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		e := s.getExecutor(ctx)
+
+		qTeam := `SELECT name, required_reviewers, review_sla_seconds, review_sla_auto_reassign FROM teams WHERE name = ?`
+		err := e.QueryRowContext(ctx, qTeam, name).
+			Scan(&team.Name, &team.RequiredReviewers, &team.ReviewSLASeconds, &team.ReviewSLAAutoReassign)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errs.NotFoundErr
+			}
+			return fmt.Errorf("sqlite failed to query team: %w", err)
+		}
+
+		qMembers := `SELECT id, username, is_active, chat_handle, email FROM users WHERE team_name = ? ORDER BY rowid`
+		rows, err := e.QueryContext(ctx, qMembers, team.Name)
+		if err != nil {
+			return fmt.Errorf("sqlite failed to query team members: %w", err)
+		}
+		defer rows.Close()
+
+		team.Members = []model.TeamMember{}
+		for rows.Next() {
+			var member model.TeamMember
+			if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.ChatHandle, &member.Email); err != nil {
+				return fmt.Errorf("sqlite failed to scan team member row: %w", err)
+			}
+			team.Members = append(team.Members, member)
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("sqlite failed to read team member rows: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// SetTeamRequiredReviewers updates number of reviewers required for pull requests of team members.
+func (s *Storage) SetTeamRequiredReviewers(ctx context.Context, name string, requiredReviewers int) error {
+	q := `UPDATE teams SET required_reviewers = ? WHERE name = ?`
+	res, err := s.getExecutor(ctx).ExecContext(ctx, q, requiredReviewers, name)
+	if err != nil {
+		return fmt.Errorf("sqlite failed to execute update team query: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlite failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errs.NotFoundErr
+	}
+	return nil
+}
+
+// SetTeamReviewSLA updates review SLA of team.
+func (s *Storage) SetTeamReviewSLA(ctx context.Context, name string, slaSeconds int, autoReassign bool) error {
+	q := `UPDATE teams SET review_sla_seconds = ?, review_sla_auto_reassign = ? WHERE name = ?`
+	res, err := s.getExecutor(ctx).ExecContext(ctx, q, slaSeconds, autoReassign, name)
+	if err != nil {
+		return fmt.Errorf("sqlite failed to execute update team review sla query: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("sqlite failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return errs.NotFoundErr
+	}
+	return nil
+}
+
+// GetTeamLeads returns ids of leads of team ordered by id.
+func (s *Storage) GetTeamLeads(ctx context.Context, teamName string) ([]string, error) {
+	q := `SELECT user_id FROM team_leads WHERE team_name = ? ORDER BY user_id`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute get team leads query: %w", err)
+	}
+
+	return collectStrings(rows)
+}
+
+// SetTeamLeads replaces leads of team.
+func (s *Storage) SetTeamLeads(ctx context.Context, teamName string, userIDs []string) error {
+	return s.InTransaction(ctx, func(ctx context.Context) error {
+		e := s.getExecutor(ctx)
+
+		var exists bool
+		if err := e.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM teams WHERE name = ?)`, teamName).Scan(&exists); err != nil {
+			return fmt.Errorf("sqlite failed to query team: %w", err)
+		}
+		if !exists {
+			return errs.NotFoundErr
+		}
+
+		if _, err := e.ExecContext(ctx, `DELETE FROM team_leads WHERE team_name = ?`, teamName); err != nil {
+			return fmt.Errorf("sqlite failed to delete team leads: %w", err)
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+
+		builder := squirrelBuilder.Insert("team_leads").
+			Columns("team_name", "user_id").
+			Suffix("ON CONFLICT DO NOTHING")
+		for _, id := range userIDs {
+			builder = builder.Values(teamName, id)
+		}
+
+		q, vals, err := builder.ToSql()
+		if err != nil {
+			return fmt.Errorf("squirrel failed to build query: %w", err)
+		}
+
+		if _, err := e.ExecContext(ctx, q, vals...); err != nil {
+			if errorCode(err) == ForeignKeyViolationErr {
+				return errs.NotFoundErr
+			}
+			return fmt.Errorf("sqlite failed to execute insert team leads query: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+const userTokenColumns = `id, user_id, name, created_at, revoked_at`
+
+// AddUserToken stores hash of user api token.
+func (s *Storage) AddUserToken(ctx context.Context, token *model.UserToken, tokenHash string) (*model.UserToken, error) {
+	q := `INSERT INTO user_tokens (user_id, name, token_hash, created_at)
+		  VALUES (?, ?, ?, ?) RETURNING ` + userTokenColumns
+	created, err := scanUserToken(s.getExecutor(ctx).QueryRowContext(ctx, q, token.UserID, token.Name, tokenHash, token.CreatedAt))
+	if err != nil {
+		if errorCode(err) == ForeignKeyViolationErr {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("sqlite failed to execute insert user token query: %w", err)
+	}
+	return &created, nil
+}
+
+// GetUserTokens retrieves all tokens of user including revoked ones.
+func (s *Storage) GetUserTokens(ctx context.Context, userID string) ([]model.UserToken, error) {
+	q := `SELECT ` + userTokenColumns + ` FROM user_tokens WHERE user_id = ? ORDER BY id`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute get user tokens query: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []model.UserToken{}
+	for rows.Next() {
+		token, err := scanUserToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan user token row: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read user token rows: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// RevokeUserToken marks token as revoked. Revocation time of already revoked token is kept.
+func (s *Storage) RevokeUserToken(ctx context.Context, id int64, revokedAt time.Time) (*model.UserToken, error) {
+	q := `UPDATE user_tokens SET revoked_at = COALESCE(revoked_at, ?2)
+		  WHERE id = ?1 RETURNING ` + userTokenColumns
+	revoked, err := scanUserToken(s.getExecutor(ctx).QueryRowContext(ctx, q, id, revokedAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("sqlite failed to execute revoke user token query: %w", err)
+	}
+	return &revoked, nil
+}
+
+// GetUserByTokenHash finds owner of not revoked token.
+func (s *Storage) GetUserByTokenHash(ctx context.Context, tokenHash string) (*model.User, error) {
+	q := `SELECT u.id, u.username, u.team_name, u.is_active, u.chat_handle, u.email FROM users u
+		  JOIN user_tokens t ON t.user_id = u.id
+		  WHERE t.token_hash = ? AND t.revoked_at IS NULL`
+	user, err := scanUser(s.getExecutor(ctx).QueryRowContext(ctx, q, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("sqlite failed to execute query: %w", err)
+	}
+	return &user, nil
+}
+
+func scanUserToken(row scanner) (model.UserToken, error) {
+	var token model.UserToken
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.CreatedAt, &token.RevokedAt)
+	return token, err
+}
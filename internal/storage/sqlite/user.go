@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+)
+
+const userColumns = `id, username, team_name, is_active, chat_handle, email`
+
+// AddUpdateUsers handles bulk insertion and updating of users using ON CONFLICT.
+// Empty chat handle or email of existing user keeps the stored one.
+func (s *Storage) AddUpdateUsers(ctx context.Context, users []model.User) ([]model.User, error) {
+	if len(users) == 0 {
+		return []model.User{}, nil
+	}
+
+	builder := squirrelBuilder.Insert("users").
+		Columns("id", "username", "team_name", "is_active", "chat_handle", "email").
+		Suffix(`ON CONFLICT (id) DO UPDATE SET
+            username = excluded.username,
+            team_name = excluded.team_name,
+            is_active = excluded.is_active,
+            chat_handle = COALESCE(NULLIF(excluded.chat_handle, ''), users.chat_handle),
+            email = COALESCE(NULLIF(excluded.email, ''), users.email)
+			RETURNING ` + userColumns)
+	for _, user := range users {
+		builder = builder.Values(user.Id, user.Username, user.TeamName, user.IsActive, user.ChatHandle, user.Email)
+	}
+
+	query, vals, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("squirrel failed to build query: %w", err)
+	}
+
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, query, vals...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute query: %w", err)
+	}
+
+	return collectUsers(rows)
+}
+
+// SetUserActivity updates the is_active status for a single user by ID.
+func (s *Storage) SetUserActivity(ctx context.Context, id string, active bool) (*model.User, error) {
+	q := `UPDATE users SET is_active = ? WHERE id = ? RETURNING ` + userColumns
+	user, err := scanUser(s.getExecutor(ctx).QueryRowContext(ctx, q, active, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("sqlite failed to execute query: %w", err)
+	}
+	return &user, nil
+}
+
+// SetTeamActivity updates the is_active status for all members of a team.
+func (s *Storage) SetTeamActivity(ctx context.Context, teamName string, active bool) ([]model.User, error) {
+	q := `UPDATE users SET is_active = ? WHERE team_name = ? RETURNING ` + userColumns
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, active, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute query: %w", err)
+	}
+
+	return collectUsers(rows)
+}
+
+// GetUser retrieves a single user by ID.
+func (s *Storage) GetUser(ctx context.Context, id string) (*model.User, error) {
+	q := `SELECT ` + userColumns + ` FROM users WHERE id = ?`
+	user, err := scanUser(s.getExecutor(ctx).QueryRowContext(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFoundErr
+		}
+		return nil, fmt.Errorf("sqlite failed to execute query: %w", err)
+	}
+	return &user, nil
+}
+
+// GetActiveColleges finds IDs of all active users belonging to the same team as the given userID (excluding userID itself).
+func (s *Storage) GetActiveColleges(ctx context.Context, userID string) ([]string, error) {
+	q := `SELECT id FROM users
+		  WHERE is_active = TRUE AND team_name =
+		  		  (SELECT team_name FROM users WHERE id = ?1)
+		  	  AND id <> ?1`
+	rows, err := s.getExecutor(ctx).QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite failed to execute query: %w", err)
+	}
+
+	return collectStrings(rows)
+}
+
+func scanUser(row scanner) (model.User, error) {
+	var user model.User
+	err := row.Scan(&user.Id, &user.Username, &user.TeamName, &user.IsActive, &user.ChatHandle, &user.Email)
+	return user, err
+}
+
+func collectUsers(rows *sql.Rows) ([]model.User, error) {
+	defer rows.Close()
+
+	users := []model.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite failed to scan user row: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite failed to read user rows: %w", err)
+	}
+
+	return users, nil
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"review-assigner/internal/model"
 )
@@ -9,8 +10,15 @@ import (
 type Storage interface {
 	Team
 	User
+	UserToken
+	ExternalIdentity
 	PullRequest
 	ReviewAssignment
+	AssignmentEvent
+	Outbox
+	Digest
+	Escalation
+	Stats
 
 	// InTransaction executes given function in a transaction.
 	// The transaction will be committed if fn returns nil, or rolled back otherwise.
@@ -19,25 +27,75 @@ type Storage interface {
 }
 
 type Team interface {
-	AddTeam(ctx context.Context, name string) (string, error)
+	// AddTeam creates team without members. Returned team has no members too.
+	AddTeam(ctx context.Context, name string, requiredReviewers int) (*model.Team, error)
 	GetTeam(ctx context.Context, name string) (*model.Team, error)
+	SetTeamRequiredReviewers(ctx context.Context, name string, requiredReviewers int) error
+
+	// SetTeamReviewSLA updates review SLA of team, zero slaSeconds disables it.
+	SetTeamReviewSLA(ctx context.Context, name string, slaSeconds int, autoReassign bool) error
+
+	// GetTeamLeads returns ids of leads of team ordered by id.
+	GetTeamLeads(ctx context.Context, teamName string) ([]string, error)
+
+	// SetTeamLeads replaces leads of team with userIDs.
+	SetTeamLeads(ctx context.Context, teamName string, userIDs []string) error
 }
 
 type User interface {
 	AddUpdateUsers(ctx context.Context, users []model.User) ([]model.User, error)
 	SetUserActivity(ctx context.Context, id string, active bool) (*model.User, error)
+	GetUser(ctx context.Context, id string) (*model.User, error)
+
+	// SetTeamActivity updates is_active of all team members and returns them.
+	SetTeamActivity(ctx context.Context, teamName string, active bool) ([]model.User, error)
 
 	// GetActiveColleges returns userIDs of users in the same team as userID excluding userID itself.
 	GetActiveColleges(ctx context.Context, userID string) ([]string, error)
 }
 
+// UserToken stores hashes of user api tokens.
+type UserToken interface {
+	// AddUserToken stores token with tokenHash and returns it with assigned ID.
+	AddUserToken(ctx context.Context, token *model.UserToken, tokenHash string) (*model.UserToken, error)
+	GetUserTokens(ctx context.Context, userID string) ([]model.UserToken, error)
+
+	// RevokeUserToken sets RevokedAt of token unless it is already revoked.
+	RevokeUserToken(ctx context.Context, id int64, revokedAt time.Time) (*model.UserToken, error)
+
+	// GetUserByTokenHash returns owner of not revoked token with tokenHash.
+	GetUserByTokenHash(ctx context.Context, tokenHash string) (*model.User, error)
+}
+
+// ExternalIdentity maps logins in external systems to users.
+type ExternalIdentity interface {
+	// SetExternalIdentity maps login to user, replacing previous mapping of login.
+	SetExternalIdentity(ctx context.Context, identity model.ExternalIdentity) error
+
+	// GetExternalIdentities returns identities of user ordered by provider and login.
+	GetExternalIdentities(ctx context.Context, userID string) ([]model.ExternalIdentity, error)
+	DeleteExternalIdentity(ctx context.Context, provider, login string) error
+
+	// GetUserByExternalIdentity returns user mapped to login.
+	GetUserByExternalIdentity(ctx context.Context, provider, login string) (*model.User, error)
+}
+
 type PullRequest interface {
 	CreatePullRequestWithAssignments(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error)
 	GetPullRequest(ctx context.Context, id string) (*model.PullRequest, error)
 
 	// UpdatePullRequest does not update review assignments!
-	// Use DeleteReviewAssignment and AddReviewAssignment for this purpose.
+	// Use DeleteReviewAssignment and AddReviewAssignment for this purpose,
+	// and record the change with AddAssignmentEvents.
 	UpdatePullRequest(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error)
+
+	// GetUnderstaffedPullRequests returns open pull requests that need more reviewers
+	// and whose authors belong to teamName. Empty teamName matches all teams.
+	GetUnderstaffedPullRequests(ctx context.Context, teamName string) ([]model.PullRequest, error)
+
+	// UpdateNeedMoreReviewers marks open pull requests of teamName authors as needing more reviewers
+	// if they have less than requiredReviewers assigned, and unmarks the rest.
+	UpdateNeedMoreReviewers(ctx context.Context, teamName string, requiredReviewers int) error
 }
 
 type ReviewAssignment interface {
@@ -46,4 +104,70 @@ type ReviewAssignment interface {
 
 	// GetUserAssignments returns pull requests where user is one of reviewers
 	GetUserAssignments(ctx context.Context, userID string) ([]model.PullRequestShort, error)
+
+	// GetOpenAssignmentCounts returns number of open pull requests each of userIDs reviews.
+	// Users without open assignments are present in result with zero count.
+	GetOpenAssignmentCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+}
+
+// AssignmentEvent is append-only history of review assignments.
+type AssignmentEvent interface {
+	AddAssignmentEvents(ctx context.Context, events []model.AssignmentEvent) error
+
+	// GetAssignmentEvents returns events of pull request in order they were added.
+	GetAssignmentEvents(ctx context.Context, prID string) ([]model.AssignmentEvent, error)
+}
+
+// Outbox keeps domain events until sinks process them.
+// Messages are added in the same transaction as the change they describe.
+type Outbox interface {
+	AddOutboxMessages(ctx context.Context, messages []model.OutboxMessage) error
+
+	// ClaimOutboxMessages returns pending messages with NextAttemptAt not after now, oldest first,
+	// and postpones their NextAttemptAt by lease, so concurrent dispatchers skip them meanwhile.
+	ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxMessage, error)
+
+	// UpdateOutboxMessage saves status, attempts, next attempt time, last error and processing time.
+	UpdateOutboxMessage(ctx context.Context, message *model.OutboxMessage) error
+}
+
+// Digest keeps subscriptions of users to email digests of pending reviews.
+// Users are subscribed unless they opted out.
+type Digest interface {
+	SetDigestOptOut(ctx context.Context, userID string, optOut bool) error
+
+	// GetDigestRecipients returns active users with email who haven't opted out, ordered by id.
+	GetDigestRecipients(ctx context.Context) ([]model.User, error)
+
+	// ClaimDigest records that digest of user is sent at sentAt unless it was already sent not before since.
+	// It returns false in the latter case, so digest is sent once even by concurrent instances.
+	ClaimDigest(ctx context.Context, userID string, sentAt, since time.Time) (bool, error)
+}
+
+// Escalation keeps track of pull requests which exceeded review SLA of author's team.
+// Pull request is escalated at most once.
+type Escalation interface {
+	// GetStalePullRequestIDs returns ids of open pull requests which are not escalated yet
+	// and were created at least review SLA of author's team before now, oldest first.
+	GetStalePullRequestIDs(ctx context.Context, now time.Time) ([]string, error)
+
+	// AddPullRequestEscalation records escalation of pull request unless it is already escalated.
+	// It returns false in the latter case, so pull request is escalated once even by concurrent instances.
+	AddPullRequestEscalation(ctx context.Context, prID string, escalatedAt time.Time) (bool, error)
+}
+
+// Stats provides aggregates over review assignments.
+type Stats interface {
+	GetUserStats(ctx context.Context) ([]model.UserStats, error)
+
+	// GetTeamStats aggregates by current team of users: assignments of users who moved to another team
+	// and reassignments from them are counted for the new team.
+	GetTeamStats(ctx context.Context) ([]model.TeamStats, error)
+
+	// GetReassignmentCounts returns number of reassignments grouped by reason.
+	GetReassignmentCounts(ctx context.Context) (map[string]int, error)
+
+	// GetAverageTimeToMerge returns average number of seconds between creation and merge of pull requests.
+	// Nil is returned if no pull request is merged.
+	GetAverageTimeToMerge(ctx context.Context) (*float64, error)
 }
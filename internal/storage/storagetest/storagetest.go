@@ -0,0 +1,1213 @@
+// Package storagetest provides behavioral contract every storage.Storage implementation must satisfy.
+//
+// Implementations run it from their tests:
+//
+//	func TestStorage(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storage.Storage {
+//			return memory.New()
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"review-assigner/internal/errs"
+	"review-assigner/internal/model"
+	"review-assigner/internal/storage"
+)
+
+// Factory returns empty storage. It is called once per subtest,
+// and is responsible for cleaning up the storage afterward, e.g. with t.Cleanup.
+type Factory func(t *testing.T) storage.Storage
+
+// Run runs the whole contract against storages made by newStorage.
+func Run(t *testing.T, newStorage Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s storage.Storage)
+	}{
+		{"AddTeamDuplicate", testAddTeamDuplicate},
+		{"GetTeamNotFound", testGetTeamNotFound},
+		{"SetTeamRequiredReviewers", testSetTeamRequiredReviewers},
+		{"TeamLeads", testTeamLeads},
+		{"AddUpdateUsersUpserts", testAddUpdateUsersUpserts},
+		{"UserNotFound", testUserNotFound},
+		{"SetTeamActivity", testSetTeamActivity},
+		{"GetActiveColleges", testGetActiveColleges},
+		{"InTransactionCommits", testInTransactionCommits},
+		{"InTransactionRollsBack", testInTransactionRollsBack},
+		{"InTransactionNested", testInTransactionNested},
+		{"CreatePullRequestDuplicate", testCreatePullRequestDuplicate},
+		{"CreatePullRequestWithoutReviewers", testCreatePullRequestWithoutReviewers},
+		{"PullRequestNotFound", testPullRequestNotFound},
+		{"UpdatePullRequestKeepsAssignments", testUpdatePullRequestKeepsAssignments},
+		{"ReviewAssignments", testReviewAssignments},
+		{"AddReviewAssignmentErrors", testAddReviewAssignmentErrors},
+		{"GetOpenAssignmentCounts", testGetOpenAssignmentCounts},
+		{"GetUnderstaffedPullRequests", testGetUnderstaffedPullRequests},
+		{"UpdateNeedMoreReviewers", testUpdateNeedMoreReviewers},
+		{"AssignmentEvents", testAssignmentEvents},
+		{"Stats", testStats},
+		{"UserTokens", testUserTokens},
+		{"ExternalIdentities", testExternalIdentities},
+		{"Outbox", testOutbox},
+		{"OutboxConcurrentClaims", testOutboxConcurrentClaims},
+		{"Digests", testDigests},
+		{"Escalations", testEscalations},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStorage(t))
+		})
+	}
+}
+
+func testAddTeamDuplicate(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	team, err := s.AddTeam(ctx, "backend", 3)
+	if err != nil {
+		t.Fatalf("AddTeam: %v", err)
+	}
+	if team.Name != "backend" || team.RequiredReviewers != 3 {
+		t.Fatalf("AddTeam returned %+v", team)
+	}
+
+	_, err = s.AddTeam(ctx, "backend", 2)
+	var teamErr errs.TeamExistsError
+	if !errors.As(err, &teamErr) {
+		t.Fatalf("AddTeam duplicate: want TeamExistsError, got %v", err)
+	}
+}
+
+func testGetTeamNotFound(t *testing.T, s storage.Storage) {
+	_, err := s.GetTeam(context.Background(), "missing")
+	requireNotFound(t, err)
+}
+
+func testSetTeamRequiredReviewers(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend", model.User{Id: "u1", Username: "Alice", IsActive: true})
+
+	if err := s.SetTeamRequiredReviewers(ctx, "backend", 1); err != nil {
+		t.Fatalf("SetTeamRequiredReviewers: %v", err)
+	}
+	team, err := s.GetTeam(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetTeam: %v", err)
+	}
+	if team.RequiredReviewers != 1 {
+		t.Fatalf("want 1 required reviewer, got %d", team.RequiredReviewers)
+	}
+	if len(team.Members) != 1 || team.Members[0].UserID != "u1" {
+		t.Fatalf("unexpected members %+v", team.Members)
+	}
+
+	requireNotFound(t, s.SetTeamRequiredReviewers(ctx, "missing", 1))
+}
+
+func testTeamLeads(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+	)
+
+	leads, err := s.GetTeamLeads(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetTeamLeads: %v", err)
+	}
+	if len(leads) != 0 {
+		t.Fatalf("want no leads, got %v", leads)
+	}
+
+	if err := s.SetTeamLeads(ctx, "backend", []string{"u2", "u1"}); err != nil {
+		t.Fatalf("SetTeamLeads: %v", err)
+	}
+	leads, err = s.GetTeamLeads(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetTeamLeads: %v", err)
+	}
+	if !slices.Equal(leads, []string{"u1", "u2"}) {
+		t.Fatalf("want leads [u1 u2], got %v", leads)
+	}
+
+	// leads are replaced, not appended
+	if err := s.SetTeamLeads(ctx, "backend", []string{"u2"}); err != nil {
+		t.Fatalf("SetTeamLeads: %v", err)
+	}
+	leads, err = s.GetTeamLeads(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetTeamLeads: %v", err)
+	}
+	if !slices.Equal(leads, []string{"u2"}) {
+		t.Fatalf("want leads [u2], got %v", leads)
+	}
+
+	requireNotFound(t, s.SetTeamLeads(ctx, "missing", []string{"u1"}))
+	requireNotFound(t, s.SetTeamLeads(ctx, "backend", []string{"missing"}))
+}
+
+func testAddUpdateUsersUpserts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend", model.User{Id: "u1", Username: "Alice", IsActive: true})
+	addTeam(t, s, "frontend")
+
+	users, err := s.AddUpdateUsers(ctx, []model.User{
+		{Id: "u1", Username: "Alice Smith", TeamName: "frontend", IsActive: false, ChatHandle: "alice"},
+		{Id: "u2", Username: "Bob", TeamName: "frontend", IsActive: true},
+	})
+	if err != nil {
+		t.Fatalf("AddUpdateUsers: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("want 2 users, got %+v", users)
+	}
+
+	user, err := s.GetUser(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	want := model.User{Id: "u1", Username: "Alice Smith", TeamName: "frontend", IsActive: false, ChatHandle: "alice"}
+	if *user != want {
+		t.Fatalf("want %+v, got %+v", want, *user)
+	}
+
+	frontend, err := s.GetTeam(ctx, "frontend")
+	if err != nil {
+		t.Fatalf("GetTeam: %v", err)
+	}
+	wantMember := model.TeamMember{UserID: "u1", Username: "Alice Smith", IsActive: false, ChatHandle: "alice"}
+	if !slices.Contains(frontend.Members, wantMember) {
+		t.Fatalf("want member %+v, got %+v", wantMember, frontend.Members)
+	}
+
+	backend, err := s.GetTeam(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetTeam: %v", err)
+	}
+	if len(backend.Members) != 0 {
+		t.Fatalf("user moved to other team is still member of old one: %+v", backend.Members)
+	}
+
+	// omitted chat handle and email keep stored ones
+	_, err = s.AddUpdateUsers(ctx, []model.User{
+		{Id: "u2", Username: "Bob", TeamName: "frontend", IsActive: true, Email: "bob@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("AddUpdateUsers: %v", err)
+	}
+	users, err = s.AddUpdateUsers(ctx, []model.User{
+		{Id: "u1", Username: "Alice", TeamName: "frontend", IsActive: true},
+		{Id: "u2", Username: "Bob", TeamName: "frontend", IsActive: true, ChatHandle: "bob"},
+	})
+	if err != nil {
+		t.Fatalf("AddUpdateUsers: %v", err)
+	}
+	wantUsers := []model.User{
+		{Id: "u1", Username: "Alice", TeamName: "frontend", IsActive: true, ChatHandle: "alice"},
+		{Id: "u2", Username: "Bob", TeamName: "frontend", IsActive: true, ChatHandle: "bob", Email: "bob@example.com"},
+	}
+	for _, want := range wantUsers {
+		if !slices.Contains(users, want) {
+			t.Fatalf("want returned user %+v, got %+v", want, users)
+		}
+		user, err := s.GetUser(ctx, want.Id)
+		if err != nil {
+			t.Fatalf("GetUser: %v", err)
+		}
+		if *user != want {
+			t.Fatalf("want %+v, got %+v", want, *user)
+		}
+	}
+}
+
+func testUserNotFound(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	_, err := s.GetUser(ctx, "missing")
+	requireNotFound(t, err)
+
+	_, err = s.SetUserActivity(ctx, "missing", true)
+	requireNotFound(t, err)
+}
+
+func testSetTeamActivity(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+	)
+	addTeam(t, s, "frontend", model.User{Id: "u3", Username: "Carol", IsActive: true})
+
+	users, err := s.SetTeamActivity(ctx, "backend", false)
+	if err != nil {
+		t.Fatalf("SetTeamActivity: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("want 2 users, got %+v", users)
+	}
+	for _, user := range users {
+		if user.IsActive {
+			t.Fatalf("user %s is still active", user.Id)
+		}
+	}
+
+	other, err := s.GetUser(ctx, "u3")
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if !other.IsActive {
+		t.Fatalf("member of other team was deactivated")
+	}
+}
+
+func testGetActiveColleges(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u3", Username: "Carol", IsActive: false},
+	)
+	addTeam(t, s, "frontend", model.User{Id: "u4", Username: "Dave", IsActive: true})
+
+	colleges, err := s.GetActiveColleges(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetActiveColleges: %v", err)
+	}
+	requireSameElements(t, []string{"u2"}, colleges)
+
+	colleges, err = s.GetActiveColleges(ctx, "u3")
+	if err != nil {
+		t.Fatalf("GetActiveColleges: %v", err)
+	}
+	requireSameElements(t, []string{"u1", "u2"}, colleges)
+}
+
+func testInTransactionCommits(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		_, err := s.AddTeam(ctx, "backend", 2)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("InTransaction: %v", err)
+	}
+
+	if _, err := s.GetTeam(ctx, "backend"); err != nil {
+		t.Fatalf("team added in committed transaction is missing: %v", err)
+	}
+}
+
+func testInTransactionRollsBack(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend", model.User{Id: "u1", Username: "Alice", IsActive: true})
+
+	fnErr := errors.New("fn failed")
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.AddTeam(ctx, "frontend", 2); err != nil {
+			return err
+		}
+		if _, err := s.SetUserActivity(ctx, "u1", false); err != nil {
+			return err
+		}
+		return fnErr
+	})
+	if !errors.Is(err, fnErr) {
+		t.Fatalf("InTransaction: want fn error, got %v", err)
+	}
+
+	_, err = s.GetTeam(ctx, "frontend")
+	requireNotFound(t, err)
+
+	user, err := s.GetUser(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if !user.IsActive {
+		t.Fatalf("user activity change was not rolled back")
+	}
+}
+
+func testInTransactionNested(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	fnErr := errors.New("fn failed")
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		err := s.InTransaction(ctx, func(ctx context.Context) error {
+			_, err := s.AddTeam(ctx, "backend", 2)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return fnErr
+	})
+	if !errors.Is(err, fnErr) {
+		t.Fatalf("InTransaction: want fn error, got %v", err)
+	}
+
+	// nested transaction is part of outer one and is rolled back with it
+	_, err = s.GetTeam(ctx, "backend")
+	requireNotFound(t, err)
+}
+
+func testCreatePullRequestDuplicate(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+	)
+	createPullRequest(t, s, "pr1", "u1", "u2")
+
+	_, err := s.CreatePullRequestWithAssignments(ctx, newPullRequest("pr1", "u1"))
+	var prErr errs.PullRequestExistsError
+	if !errors.As(err, &prErr) {
+		t.Fatalf("CreatePullRequestWithAssignments duplicate: want PullRequestExistsError, got %v", err)
+	}
+}
+
+func testCreatePullRequestWithoutReviewers(t *testing.T, s storage.Storage) {
+	addTeam(t, s, "backend", model.User{Id: "u1", Username: "Alice", IsActive: true})
+
+	pr := createPullRequest(t, s, "pr1", "u1")
+	if len(pr.AssignedReviewers) != 0 {
+		t.Fatalf("want no reviewers, got %v", pr.AssignedReviewers)
+	}
+}
+
+func testPullRequestNotFound(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	_, err := s.GetPullRequest(ctx, "missing")
+	requireNotFound(t, err)
+
+	_, err = s.UpdatePullRequest(ctx, newPullRequest("missing", "u1"))
+	requireNotFound(t, err)
+}
+
+func testUpdatePullRequestKeepsAssignments(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u3", Username: "Carol", IsActive: true},
+	)
+	pr := createPullRequest(t, s, "pr1", "u1", "u2", "u3")
+
+	mergedAt := pr.CreatedAt.Add(time.Hour)
+	pr.Status = model.PullRequestStatusMERGED
+	pr.MergedAt = &mergedAt
+	pr.NeedMoreReviewers = true
+	pr.AssignedReviewers = []string{"u2"}
+
+	updated, err := s.UpdatePullRequest(ctx, pr)
+	if err != nil {
+		t.Fatalf("UpdatePullRequest: %v", err)
+	}
+	if updated.Status != model.PullRequestStatusMERGED || updated.MergedAt == nil || !updated.NeedMoreReviewers {
+		t.Fatalf("pull request was not updated: %+v", updated)
+	}
+
+	stored, err := s.GetPullRequest(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	requireSameElements(t, []string{"u2", "u3"}, stored.AssignedReviewers)
+	if stored.Status != model.PullRequestStatusMERGED || !stored.NeedMoreReviewers {
+		t.Fatalf("pull request was not stored: %+v", stored)
+	}
+}
+
+func testReviewAssignments(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u3", Username: "Carol", IsActive: true},
+	)
+	createPullRequest(t, s, "pr1", "u1", "u2")
+	createPullRequest(t, s, "pr2", "u1", "u2")
+
+	if err := s.DeleteReviewAssignment(ctx, "pr1", "u2"); err != nil {
+		t.Fatalf("DeleteReviewAssignment: %v", err)
+	}
+	reviewerID, err := s.AddReviewAssignment(ctx, "pr1", "u3")
+	if err != nil {
+		t.Fatalf("AddReviewAssignment: %v", err)
+	}
+	if reviewerID != "u3" {
+		t.Fatalf("want reviewer u3, got %s", reviewerID)
+	}
+
+	pr, err := s.GetPullRequest(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	requireSameElements(t, []string{"u3"}, pr.AssignedReviewers)
+
+	createPullRequest(t, s, "pr3", "u1", "u3")
+	assignments, err := s.GetUserAssignments(ctx, "u3")
+	if err != nil {
+		t.Fatalf("GetUserAssignments: %v", err)
+	}
+	ids := make([]string, len(assignments))
+	for i, a := range assignments {
+		ids[i] = a.Id
+	}
+	requireSameElements(t, []string{"pr1", "pr3"}, ids)
+}
+
+func testAddReviewAssignmentErrors(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+	)
+	createPullRequest(t, s, "pr1", "u1", "u2")
+
+	_, err := s.AddReviewAssignment(ctx, "pr1", "u2")
+	var assignmentErr errs.ReviewAssignmentExistsError
+	if !errors.As(err, &assignmentErr) {
+		t.Fatalf("AddReviewAssignment duplicate: want ReviewAssignmentExistsError, got %v", err)
+	}
+
+	_, err = s.AddReviewAssignment(ctx, "missing", "u2")
+	requireNotFound(t, err)
+
+	_, err = s.AddReviewAssignment(ctx, "pr1", "missing")
+	requireNotFound(t, err)
+}
+
+func testGetOpenAssignmentCounts(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u3", Username: "Carol", IsActive: true},
+	)
+	createPullRequest(t, s, "pr1", "u1", "u2", "u3")
+	createPullRequest(t, s, "pr2", "u1", "u2")
+	merged := createPullRequest(t, s, "pr3", "u1", "u3")
+
+	merged.Status = model.PullRequestStatusMERGED
+	if _, err := s.UpdatePullRequest(ctx, merged); err != nil {
+		t.Fatalf("UpdatePullRequest: %v", err)
+	}
+
+	counts, err := s.GetOpenAssignmentCounts(ctx, []string{"u1", "u2", "u3"})
+	if err != nil {
+		t.Fatalf("GetOpenAssignmentCounts: %v", err)
+	}
+	want := map[string]int{"u1": 0, "u2": 2, "u3": 1}
+	for id, count := range want {
+		if got, ok := counts[id]; !ok || got != count {
+			t.Fatalf("want %v, got %v", want, counts)
+		}
+	}
+}
+
+func testGetUnderstaffedPullRequests(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend", model.User{Id: "u1", Username: "Alice", IsActive: true})
+	addTeam(t, s, "frontend", model.User{Id: "u2", Username: "Bob", IsActive: true})
+
+	for _, pr := range []*model.PullRequest{newPullRequest("pr1", "u1"), newPullRequest("pr2", "u2"), newPullRequest("pr3", "u1")} {
+		pr.NeedMoreReviewers = pr.Id != "pr3"
+		if _, err := s.CreatePullRequestWithAssignments(ctx, pr); err != nil {
+			t.Fatalf("CreatePullRequestWithAssignments: %v", err)
+		}
+	}
+
+	prs, err := s.GetUnderstaffedPullRequests(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetUnderstaffedPullRequests: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Id != "pr1" {
+		t.Fatalf("want pr1, got %+v", prs)
+	}
+
+	prs, err = s.GetUnderstaffedPullRequests(ctx, "")
+	if err != nil {
+		t.Fatalf("GetUnderstaffedPullRequests: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("want 2 pull requests, got %+v", prs)
+	}
+}
+
+func testUpdateNeedMoreReviewers(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u3", Username: "Carol", IsActive: true})
+	addTeam(t, s, "frontend", model.User{Id: "f1", Username: "Dave", IsActive: true})
+
+	createPullRequest(t, s, "pr1", "u1", "u2")
+	createPullRequest(t, s, "pr2", "u1", "u2", "u3")
+	createPullRequest(t, s, "pr3", "f1")
+	merged := newPullRequest("pr4", "u1")
+	merged.Status = model.PullRequestStatusMERGED
+	if _, err := s.CreatePullRequestWithAssignments(ctx, merged); err != nil {
+		t.Fatalf("CreatePullRequestWithAssignments: %v", err)
+	}
+
+	understaffedIDs := func() []string {
+		t.Helper()
+		prs, err := s.GetUnderstaffedPullRequests(ctx, "")
+		if err != nil {
+			t.Fatalf("GetUnderstaffedPullRequests: %v", err)
+		}
+		ids := make([]string, len(prs))
+		for i, pr := range prs {
+			ids[i] = pr.Id
+		}
+		return ids
+	}
+
+	// merged pull requests and pull requests of other teams are not marked
+	if err := s.UpdateNeedMoreReviewers(ctx, "backend", 2); err != nil {
+		t.Fatalf("UpdateNeedMoreReviewers: %v", err)
+	}
+	requireSameElements(t, []string{"pr1"}, understaffedIDs())
+
+	if err := s.UpdateNeedMoreReviewers(ctx, "backend", 3); err != nil {
+		t.Fatalf("UpdateNeedMoreReviewers: %v", err)
+	}
+	requireSameElements(t, []string{"pr1", "pr2"}, understaffedIDs())
+
+	// pull requests with enough reviewers are unmarked
+	if err := s.UpdateNeedMoreReviewers(ctx, "backend", 1); err != nil {
+		t.Fatalf("UpdateNeedMoreReviewers: %v", err)
+	}
+	requireSameElements(t, []string{}, understaffedIDs())
+}
+
+func testAssignmentEvents(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u3", Username: "Carol", IsActive: true},
+	)
+	createPullRequest(t, s, "pr1", "u1", "u2")
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	events := []model.AssignmentEvent{
+		{PullRequestID: "pr1", Type: model.AssignmentEventASSIGNED, Actor: "admin", NewReviewerID: "u2", Reason: model.ReasonPullRequestCreated, CreatedAt: now},
+		{PullRequestID: "pr1", Type: model.AssignmentEventREASSIGNED, Actor: "admin", OldReviewerID: "u2", NewReviewerID: "u3", Reason: model.ReasonManualReassign, CreatedAt: now},
+		{PullRequestID: "pr1", Type: model.AssignmentEventESCALATED, Actor: "sla", Reason: model.ReasonReviewSLAExceeded, CreatedAt: now},
+	}
+	if err := s.AddAssignmentEvents(ctx, events); err != nil {
+		t.Fatalf("AddAssignmentEvents: %v", err)
+	}
+
+	stored, err := s.GetAssignmentEvents(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("GetAssignmentEvents: %v", err)
+	}
+	if len(stored) != len(events) {
+		t.Fatalf("want %d events, got %+v", len(events), stored)
+	}
+	for i, e := range stored {
+		want := events[i]
+		if e.Type != want.Type || e.OldReviewerID != want.OldReviewerID || e.NewReviewerID != want.NewReviewerID ||
+			e.Actor != want.Actor || e.Reason != want.Reason || !e.CreatedAt.Equal(want.CreatedAt) {
+			t.Fatalf("event %d: want %+v, got %+v", i, want, e)
+		}
+	}
+}
+
+func testStats(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u3", Username: "Carol", IsActive: true},
+	)
+	addTeam(t, s, "frontend", model.User{Id: "u4", Username: "Dave", IsActive: true})
+	addTeam(t, s, "empty")
+
+	// pull requests of backend and frontend are merged in 1 and 3 minutes
+	createdAt := time.Now().UTC().Truncate(time.Millisecond).Add(-time.Hour)
+	for _, pr := range []struct {
+		pr       *model.PullRequest
+		mergedIn time.Duration
+	}{
+		{pr: newPullRequest("pr1", "u1", "u2", "u3"), mergedIn: time.Minute},
+		{pr: newPullRequest("pr2", "u1", "u2", "u4")},
+		{pr: newPullRequest("pr3", "u4", "u3"), mergedIn: 3 * time.Minute},
+	} {
+		pr.pr.CreatedAt = &createdAt
+		if _, err := s.CreatePullRequestWithAssignments(ctx, pr.pr); err != nil {
+			t.Fatalf("CreatePullRequestWithAssignments: %v", err)
+		}
+		if pr.mergedIn == 0 {
+			continue
+		}
+		mergedAt := createdAt.Add(pr.mergedIn)
+		pr.pr.Status = model.PullRequestStatusMERGED
+		pr.pr.MergedAt = &mergedAt
+		if _, err := s.UpdatePullRequest(ctx, pr.pr); err != nil {
+			t.Fatalf("UpdatePullRequest: %v", err)
+		}
+	}
+
+	events := []model.AssignmentEvent{
+		{PullRequestID: "pr1", Type: model.AssignmentEventASSIGNED, Actor: "admin", NewReviewerID: "u3", Reason: model.ReasonPullRequestCreated},
+		{PullRequestID: "pr1", Type: model.AssignmentEventREASSIGNED, Actor: "admin", OldReviewerID: "u3", NewReviewerID: "u2", Reason: model.ReasonManualReassign},
+		{PullRequestID: "pr3", Type: model.AssignmentEventREASSIGNED, Actor: "admin", OldReviewerID: "u3", NewReviewerID: "u2", Reason: model.ReasonManualReassign},
+		{PullRequestID: "pr2", Type: model.AssignmentEventREASSIGNED, Actor: "admin", OldReviewerID: "u4", NewReviewerID: "u3", Reason: model.ReasonUserDeactivated},
+	}
+	for i := range events {
+		events[i].CreatedAt = createdAt
+	}
+	if err := s.AddAssignmentEvents(ctx, events); err != nil {
+		t.Fatalf("AddAssignmentEvents: %v", err)
+	}
+
+	userStats, err := s.GetUserStats(ctx)
+	if err != nil {
+		t.Fatalf("GetUserStats: %v", err)
+	}
+	wantUserStats := []model.UserStats{
+		{UserID: "u1", Username: "Alice", TeamName: "backend"},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", TotalAssignments: 2, OpenAssignments: 1, MergedAssignments: 1},
+		{UserID: "u3", Username: "Carol", TeamName: "backend", TotalAssignments: 2, MergedAssignments: 2, ReassignedFrom: 2},
+		{UserID: "u4", Username: "Dave", TeamName: "frontend", TotalAssignments: 1, OpenAssignments: 1, ReassignedFrom: 1},
+	}
+	if !slices.Equal(userStats, wantUserStats) {
+		t.Fatalf("want user stats %+v, got %+v", wantUserStats, userStats)
+	}
+
+	teamStats, err := s.GetTeamStats(ctx)
+	if err != nil {
+		t.Fatalf("GetTeamStats: %v", err)
+	}
+	wantTeamStats := []struct {
+		stats   model.TeamStats
+		average float64
+	}{
+		{stats: model.TeamStats{TeamName: "backend", TotalAssignments: 4, OpenAssignments: 1, MergedAssignments: 3, Reassignments: 2}, average: 60},
+		{stats: model.TeamStats{TeamName: "empty"}},
+		{stats: model.TeamStats{TeamName: "frontend", TotalAssignments: 1, OpenAssignments: 1, Reassignments: 1}, average: 180},
+	}
+	if len(teamStats) != len(wantTeamStats) {
+		t.Fatalf("want %d teams, got %+v", len(wantTeamStats), teamStats)
+	}
+	for i, want := range wantTeamStats {
+		got := teamStats[i]
+		average := got.AverageTimeToMergeSeconds
+		got.AverageTimeToMergeSeconds = nil
+		if got != want.stats {
+			t.Fatalf("want team stats %+v, got %+v", want.stats, got)
+		}
+		requireSeconds(t, want.average, average)
+	}
+
+	counts, err := s.GetReassignmentCounts(ctx)
+	if err != nil {
+		t.Fatalf("GetReassignmentCounts: %v", err)
+	}
+	if len(counts) != 2 || counts[model.ReasonManualReassign] != 2 || counts[model.ReasonUserDeactivated] != 1 {
+		t.Fatalf("unexpected reassignment counts %v", counts)
+	}
+
+	average, err := s.GetAverageTimeToMerge(ctx)
+	if err != nil {
+		t.Fatalf("GetAverageTimeToMerge: %v", err)
+	}
+	requireSeconds(t, 120, average)
+}
+
+func testUserTokens(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend", model.User{Id: "u1", Username: "Alice", IsActive: true})
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	_, err := s.AddUserToken(ctx, &model.UserToken{UserID: "missing", Name: "ci", CreatedAt: now}, "hash0")
+	requireNotFound(t, err)
+
+	token, err := s.AddUserToken(ctx, &model.UserToken{UserID: "u1", Name: "ci", CreatedAt: now}, "hash1")
+	if err != nil {
+		t.Fatalf("AddUserToken: %v", err)
+	}
+	if token.ID == 0 || token.UserID != "u1" || token.Name != "ci" || !token.CreatedAt.Equal(now) || token.RevokedAt != nil {
+		t.Fatalf("unexpected token %+v", token)
+	}
+
+	user, err := s.GetUserByTokenHash(ctx, "hash1")
+	if err != nil {
+		t.Fatalf("GetUserByTokenHash: %v", err)
+	}
+	if user.Id != "u1" {
+		t.Fatalf("want owner u1, got %+v", user)
+	}
+	_, err = s.GetUserByTokenHash(ctx, "unknown")
+	requireNotFound(t, err)
+
+	revokedAt := now.Add(time.Minute)
+	revoked, err := s.RevokeUserToken(ctx, token.ID, revokedAt)
+	if err != nil {
+		t.Fatalf("RevokeUserToken: %v", err)
+	}
+	if revoked.RevokedAt == nil || !revoked.RevokedAt.Equal(revokedAt) {
+		t.Fatalf("want token revoked at %v, got %+v", revokedAt, revoked)
+	}
+	// second revocation keeps original time
+	revoked, err = s.RevokeUserToken(ctx, token.ID, revokedAt.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("RevokeUserToken: %v", err)
+	}
+	if revoked.RevokedAt == nil || !revoked.RevokedAt.Equal(revokedAt) {
+		t.Fatalf("want token revoked at %v, got %+v", revokedAt, revoked)
+	}
+	_, err = s.RevokeUserToken(ctx, token.ID+100, revokedAt)
+	requireNotFound(t, err)
+
+	_, err = s.GetUserByTokenHash(ctx, "hash1")
+	requireNotFound(t, err)
+
+	tokens, err := s.GetUserTokens(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetUserTokens: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].ID != token.ID || tokens[0].RevokedAt == nil {
+		t.Fatalf("want single revoked token, got %+v", tokens)
+	}
+}
+
+func testExternalIdentities(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+	)
+
+	err := s.SetExternalIdentity(ctx, model.ExternalIdentity{Provider: "github", Login: "ghost", UserID: "missing"})
+	requireNotFound(t, err)
+
+	for _, identity := range []model.ExternalIdentity{
+		{Provider: "github", Login: "bob", UserID: "u1"},
+		{Provider: "github", Login: "alice", UserID: "u1"},
+		// login is mapped again to another user
+		{Provider: "github", Login: "bob", UserID: "u2"},
+	} {
+		if err := s.SetExternalIdentity(ctx, identity); err != nil {
+			t.Fatalf("SetExternalIdentity(%+v): %v", identity, err)
+		}
+	}
+
+	user, err := s.GetUserByExternalIdentity(ctx, "github", "bob")
+	if err != nil {
+		t.Fatalf("GetUserByExternalIdentity: %v", err)
+	}
+	if user.Id != "u2" {
+		t.Fatalf("want bob mapped to u2, got %+v", user)
+	}
+	_, err = s.GetUserByExternalIdentity(ctx, "gitlab", "bob")
+	requireNotFound(t, err)
+
+	identities, err := s.GetExternalIdentities(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetExternalIdentities: %v", err)
+	}
+	want := []model.ExternalIdentity{{Provider: "github", Login: "alice", UserID: "u1"}}
+	if !slices.Equal(identities, want) {
+		t.Fatalf("want identities %+v, got %+v", want, identities)
+	}
+
+	if err := s.DeleteExternalIdentity(ctx, "github", "alice"); err != nil {
+		t.Fatalf("DeleteExternalIdentity: %v", err)
+	}
+	requireNotFound(t, s.DeleteExternalIdentity(ctx, "github", "alice"))
+	_, err = s.GetUserByExternalIdentity(ctx, "github", "alice")
+	requireNotFound(t, err)
+}
+
+func testOutbox(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	message := func(sink string, nextAttemptAt time.Time) model.OutboxMessage {
+		return model.OutboxMessage{
+			Sink:          sink,
+			Type:          string(model.NotificationReviewAssigned),
+			Payload:       []byte(`{"id":"n1"}`),
+			Status:        model.OutboxMessagePENDING,
+			NextAttemptAt: nextAttemptAt,
+			CreatedAt:     now,
+		}
+	}
+	err := s.AddOutboxMessages(ctx, []model.OutboxMessage{
+		message("a", now),
+		message("b", now.Add(time.Hour)),
+		message("c", now.Add(-time.Minute)),
+	})
+	if err != nil {
+		t.Fatalf("AddOutboxMessages: %v", err)
+	}
+
+	limited, err := s.ClaimOutboxMessages(ctx, now, time.Minute, 1)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages: %v", err)
+	}
+	if len(limited) != 1 || limited[0].Sink != "c" {
+		t.Fatalf("want oldest due message to c, got %+v", limited)
+	}
+
+	claimed, err := s.ClaimOutboxMessages(ctx, now, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages: %v", err)
+	}
+	// message to c is leased already
+	if len(claimed) != 1 || claimed[0].Sink != "a" {
+		t.Fatalf("want message to a, got %+v", claimed)
+	}
+	got := claimed[0]
+	if string(got.Payload) != `{"id":"n1"}` || got.Type != string(model.NotificationReviewAssigned) || !got.CreatedAt.Equal(now) {
+		t.Fatalf("unexpected message %+v", got)
+	}
+	if !got.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("want message leased until %v, got %v", now.Add(time.Minute), got.NextAttemptAt)
+	}
+
+	done := limited[0]
+	done.Status = model.OutboxMessageDONE
+	done.Attempts = 1
+	done.ProcessedAt = &now
+	if err := s.UpdateOutboxMessage(ctx, &done); err != nil {
+		t.Fatalf("UpdateOutboxMessage: %v", err)
+	}
+	retried := got
+	retried.Attempts = 1
+	retried.LastError = "503 Service Unavailable"
+	retried.NextAttemptAt = now.Add(time.Second)
+	if err := s.UpdateOutboxMessage(ctx, &retried); err != nil {
+		t.Fatalf("UpdateOutboxMessage: %v", err)
+	}
+
+	// lease of message to c has expired, but it is done
+	claimed, err = s.ClaimOutboxMessages(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != retried.ID || claimed[0].Attempts != 1 || claimed[0].LastError != retried.LastError {
+		t.Fatalf("want retried message only, got %+v", claimed)
+	}
+
+	missing := model.OutboxMessage{ID: retried.ID + 100, Status: model.OutboxMessageFAILED}
+	requireNotFound(t, s.UpdateOutboxMessage(ctx, &missing))
+}
+
+// testOutboxConcurrentClaims checks that concurrent dispatchers never claim the same message.
+func testOutboxConcurrentClaims(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	messages := make([]model.OutboxMessage, 30)
+	for i := range messages {
+		messages[i] = model.OutboxMessage{
+			Sink:          "webhook",
+			Type:          string(model.NotificationReviewAssigned),
+			Payload:       []byte(`{}`),
+			Status:        model.OutboxMessagePENDING,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+	}
+	if err := s.AddOutboxMessages(ctx, messages); err != nil {
+		t.Fatalf("AddOutboxMessages: %v", err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		claimed   = map[int64]int{}
+		claimErrs []error
+	)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				batch, err := s.ClaimOutboxMessages(ctx, now, time.Minute, 4)
+
+				mu.Lock()
+				if err != nil {
+					claimErrs = append(claimErrs, err)
+				}
+				for _, m := range batch {
+					claimed[m.ID]++
+				}
+				mu.Unlock()
+
+				if err != nil || len(batch) == 0 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(claimErrs) > 0 {
+		t.Fatalf("ClaimOutboxMessages: %v", errors.Join(claimErrs...))
+	}
+	if len(claimed) != len(messages) {
+		t.Fatalf("want %d messages claimed, got %d", len(messages), len(claimed))
+	}
+	for id, n := range claimed {
+		if n != 1 {
+			t.Fatalf("message %d claimed %d times", id, n)
+		}
+	}
+}
+
+func testDigests(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u3", Username: "Carol", IsActive: true, Email: "carol@example.com"},
+		model.User{Id: "u1", Username: "Alice", IsActive: true, Email: "alice@example.com"},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+		model.User{Id: "u4", Username: "Dave", IsActive: false, Email: "dave@example.com"},
+	)
+
+	recipientIDs := func() []string {
+		t.Helper()
+		recipients, err := s.GetDigestRecipients(ctx)
+		if err != nil {
+			t.Fatalf("GetDigestRecipients: %v", err)
+		}
+		ids := make([]string, len(recipients))
+		for i, user := range recipients {
+			ids[i] = user.Id
+		}
+		return ids
+	}
+	if ids := recipientIDs(); !slices.Equal(ids, []string{"u1", "u3"}) {
+		t.Fatalf("want recipients [u1 u3], got %v", ids)
+	}
+
+	if err := s.SetDigestOptOut(ctx, "u3", true); err != nil {
+		t.Fatalf("SetDigestOptOut: %v", err)
+	}
+	if ids := recipientIDs(); !slices.Equal(ids, []string{"u1"}) {
+		t.Fatalf("want recipients [u1], got %v", ids)
+	}
+	if err := s.SetDigestOptOut(ctx, "u3", false); err != nil {
+		t.Fatalf("SetDigestOptOut: %v", err)
+	}
+	if ids := recipientIDs(); !slices.Equal(ids, []string{"u1", "u3"}) {
+		t.Fatalf("want recipients [u1 u3], got %v", ids)
+	}
+	requireNotFound(t, s.SetDigestOptOut(ctx, "missing", true))
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	claim := func(userID string, sentAt, since time.Time) bool {
+		t.Helper()
+		claimed, err := s.ClaimDigest(ctx, userID, sentAt, since)
+		if err != nil {
+			t.Fatalf("ClaimDigest: %v", err)
+		}
+		return claimed
+	}
+	if !claim("u1", now, now.Add(-time.Hour)) {
+		t.Fatal("want first digest claimed")
+	}
+	if claim("u1", now.Add(time.Minute), now.Add(-time.Hour)) {
+		t.Fatal("want digest claimed once since the same time")
+	}
+	if !claim("u1", now.Add(24*time.Hour), now.Add(23*time.Hour)) {
+		t.Fatal("want next digest claimed")
+	}
+	// opt out doesn't reset time of last digest
+	if err := s.SetDigestOptOut(ctx, "u1", true); err != nil {
+		t.Fatalf("SetDigestOptOut: %v", err)
+	}
+	if claim("u1", now.Add(24*time.Hour), now.Add(23*time.Hour)) {
+		t.Fatal("want digest claimed once after opt out")
+	}
+
+	_, err := s.ClaimDigest(ctx, "missing", now, now)
+	requireNotFound(t, err)
+}
+
+func testEscalations(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+	addTeam(t, s, "backend",
+		model.User{Id: "u1", Username: "Alice", IsActive: true},
+		model.User{Id: "u2", Username: "Bob", IsActive: true},
+	)
+	addTeam(t, s, "frontend",
+		model.User{Id: "u3", Username: "Carol", IsActive: true},
+		model.User{Id: "u4", Username: "Dave", IsActive: true},
+	)
+
+	if err := s.SetTeamReviewSLA(ctx, "backend", 3600, true); err != nil {
+		t.Fatalf("SetTeamReviewSLA: %v", err)
+	}
+	team, err := s.GetTeam(ctx, "backend")
+	if err != nil {
+		t.Fatalf("GetTeam: %v", err)
+	}
+	if team.ReviewSLASeconds != 3600 || !team.ReviewSLAAutoReassign {
+		t.Fatalf("want review sla 3600 with auto reassign, got %+v", team)
+	}
+	requireNotFound(t, s.SetTeamReviewSLA(ctx, "missing", 60, false))
+
+	// creation times in other time zone must be compared correctly too
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	zone := time.FixedZone("UTC+3", 3*60*60)
+	create := func(id, authorID string, age time.Duration) {
+		t.Helper()
+		pr := newPullRequest(id, authorID, "u2")
+		if authorID != "u1" {
+			pr.AssignedReviewers = []string{"u4"}
+		}
+		createdAt := now.Add(-age).In(zone)
+		pr.CreatedAt = &createdAt
+		if _, err := s.CreatePullRequestWithAssignments(ctx, pr); err != nil {
+			t.Fatalf("CreatePullRequestWithAssignments: %v", err)
+		}
+	}
+	create("pr-old", "u1", 2*time.Hour)
+	create("pr-older", "u1", 3*time.Hour)
+	create("pr-fresh", "u1", 10*time.Minute)
+	create("pr-no-sla", "u3", 3*time.Hour)
+	create("pr-merged", "u1", 3*time.Hour)
+
+	merged, err := s.GetPullRequest(ctx, "pr-merged")
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	merged.Status = model.PullRequestStatusMERGED
+	merged.MergedAt = &now
+	if _, err := s.UpdatePullRequest(ctx, merged); err != nil {
+		t.Fatalf("UpdatePullRequest: %v", err)
+	}
+
+	staleIDs := func() []string {
+		t.Helper()
+		ids, err := s.GetStalePullRequestIDs(ctx, now)
+		if err != nil {
+			t.Fatalf("GetStalePullRequestIDs: %v", err)
+		}
+		return ids
+	}
+	if ids := staleIDs(); !slices.Equal(ids, []string{"pr-older", "pr-old"}) {
+		t.Fatalf("want stale [pr-older pr-old], got %v", ids)
+	}
+
+	escalate := func(prID string) bool {
+		t.Helper()
+		added, err := s.AddPullRequestEscalation(ctx, prID, now)
+		if err != nil {
+			t.Fatalf("AddPullRequestEscalation: %v", err)
+		}
+		return added
+	}
+	if !escalate("pr-old") {
+		t.Fatal("want first escalation added")
+	}
+	if escalate("pr-old") {
+		t.Fatal("want pull request escalated once")
+	}
+	if ids := staleIDs(); !slices.Equal(ids, []string{"pr-older"}) {
+		t.Fatalf("want stale [pr-older], got %v", ids)
+	}
+
+	// disabled SLA stops escalations
+	if err := s.SetTeamReviewSLA(ctx, "backend", 0, false); err != nil {
+		t.Fatalf("SetTeamReviewSLA: %v", err)
+	}
+	if ids := staleIDs(); len(ids) != 0 {
+		t.Fatalf("want no stale pull requests, got %v", ids)
+	}
+
+	_, err = s.AddPullRequestEscalation(ctx, "missing", now)
+	requireNotFound(t, err)
+}
+
+func addTeam(t *testing.T, s storage.Storage, name string, members ...model.User) {
+	t.Helper()
+	ctx := context.Background()
+
+	if _, err := s.AddTeam(ctx, name, model.DefaultRequiredReviewers); err != nil {
+		t.Fatalf("AddTeam: %v", err)
+	}
+	if len(members) == 0 {
+		return
+	}
+
+	for i := range members {
+		members[i].TeamName = name
+	}
+	if _, err := s.AddUpdateUsers(ctx, members); err != nil {
+		t.Fatalf("AddUpdateUsers: %v", err)
+	}
+}
+
+func newPullRequest(id, authorID string, reviewers ...string) *model.PullRequest {
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+	return &model.PullRequest{
+		Id:                id,
+		Name:              "Pull request " + id,
+		AuthorID:          authorID,
+		Status:            model.PullRequestStatusOPEN,
+		AssignedReviewers: reviewers,
+		CreatedAt:         &createdAt,
+	}
+}
+
+func createPullRequest(t *testing.T, s storage.Storage, id, authorID string, reviewers ...string) *model.PullRequest {
+	t.Helper()
+
+	pr, err := s.CreatePullRequestWithAssignments(context.Background(), newPullRequest(id, authorID, reviewers...))
+	if err != nil {
+		t.Fatalf("CreatePullRequestWithAssignments: %v", err)
+	}
+	requireSameElements(t, reviewers, pr.AssignedReviewers)
+
+	return pr
+}
+
+func requireNotFound(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, errs.NotFoundErr) {
+		t.Fatalf("want NotFoundErr, got %v", err)
+	}
+}
+
+// requireSeconds checks average number of seconds, zero want means there is no average.
+func requireSeconds(t *testing.T, want float64, got *float64) {
+	t.Helper()
+	if want == 0 {
+		if got != nil {
+			t.Fatalf("want no average, got %v", *got)
+		}
+		return
+	}
+	if got == nil || math.Abs(*got-want) > 0.01 {
+		t.Fatalf("want average of %v seconds, got %v", want, got)
+	}
+}
+
+func requireSameElements(t *testing.T, want, got []string) {
+	t.Helper()
+	want, got = slices.Clone(want), slices.Clone(got)
+	slices.Sort(want)
+	slices.Sort(got)
+	if !slices.Equal(want, got) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
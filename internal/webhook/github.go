@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"review-assigner/internal/model"
+)
+
+// Headers of GitHub webhook deliveries.
+const (
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+)
+
+// GitHub verifies and parses GitHub webhook deliveries.
+type GitHub struct {
+	secret []byte
+}
+
+var _ Provider = (*GitHub)(nil)
+
+// NewGitHub returns GitHub which expects deliveries signed with secret of webhook.
+func NewGitHub(secret string) *GitHub {
+	return &GitHub{secret: []byte(secret)}
+}
+
+func (g *GitHub) Name() string {
+	return model.ProviderGitHub
+}
+
+// Verify checks HMAC-SHA256 signature of body in X-Hub-Signature-256 header.
+func (g *GitHub) Verify(header http.Header, body []byte) error {
+	signature, ok := strings.CutPrefix(header.Get(GitHubSignatureHeader), "sha256=")
+	if !ok {
+		return InvalidSignatureErr
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return InvalidSignatureErr
+	}
+
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return InvalidSignatureErr
+	}
+
+	return nil
+}
+
+// Parse ignores events other than pull_request, e.g. ping, see ParseGitHubEvent.
+func (g *GitHub) Parse(header http.Header, body []byte) (*Event, error) {
+	return ParseGitHubEvent(header.Get(GitHubEventHeader), body)
+}
+
+type gitHubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title  string `json:"title"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// ParseGitHubEvent parses payload of event of type given in X-GitHub-Event header.
+// Only opened, reopened and merged pull_request events are returned, closing without merge is ignored.
+func ParseGitHubEvent(eventType string, body []byte) (*Event, error) {
+	if eventType != "pull_request" {
+		return nil, nil
+	}
+
+	var payload gitHubPullRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", InvalidPayloadErr, err)
+	}
+
+	var action Action
+	switch {
+	case payload.Action == "opened" || payload.Action == "reopened":
+		action = ActionOpen
+	case payload.Action == "closed" && payload.PullRequest.Merged:
+		action = ActionMerge
+	default:
+		return nil, nil
+	}
+
+	if payload.Repository.FullName == "" || payload.Number == 0 || payload.PullRequest.User.Login == "" {
+		return nil, fmt.Errorf("%w: repository, number and author are required", InvalidPayloadErr)
+	}
+
+	return &Event{
+		Action:        action,
+		PullRequestID: truncate(fmt.Sprintf("%s#%d", payload.Repository.FullName, payload.Number)),
+		Title:         truncate(payload.PullRequest.Title),
+		AuthorLogin:   payload.PullRequest.User.Login,
+	}, nil
+}
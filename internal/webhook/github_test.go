@@ -0,0 +1,145 @@
+package webhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"review-assigner/internal/webhook"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return body
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestGitHubVerify(t *testing.T) {
+	const secret = "webhook-secret"
+	body := readFixture(t, "github/pull_request_opened.json")
+	github := webhook.NewGitHub(secret)
+
+	tests := []struct {
+		name      string
+		body      []byte
+		signature string
+		wantErr   bool
+	}{
+		{name: "valid signature", body: body, signature: sign(secret, body)},
+		{name: "tampered body", body: append([]byte(" "), body...), signature: sign(secret, body), wantErr: true},
+		{name: "other secret", body: body, signature: sign("other-secret", body), wantErr: true},
+		{name: "missing prefix", body: body, signature: sign(secret, body)[len("sha256="):], wantErr: true},
+		{name: "not hex", body: body, signature: "sha256=zz", wantErr: true},
+		{name: "missing header", body: body, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.signature != "" {
+				header.Set(webhook.GitHubSignatureHeader, tt.signature)
+			}
+
+			err := github.Verify(header, tt.body)
+			if tt.wantErr && !errors.Is(err, webhook.InvalidSignatureErr) {
+				t.Fatalf("want InvalidSignatureErr, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("want valid signature, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseGitHubEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		fixture   string
+		want      *webhook.Event
+	}{
+		{
+			name:      "opened",
+			eventType: "pull_request",
+			fixture:   "github/pull_request_opened.json",
+			want: &webhook.Event{
+				Action:        webhook.ActionOpen,
+				PullRequestID: "octo-org/api#42",
+				Title:         "Add retries to payment client",
+				AuthorLogin:   "OctoCat",
+			},
+		},
+		{
+			name:      "reopened",
+			eventType: "pull_request",
+			fixture:   "github/pull_request_reopened.json",
+			want: &webhook.Event{
+				Action:        webhook.ActionOpen,
+				PullRequestID: "octo-org/api#42",
+				Title:         "Add retries to payment client",
+				AuthorLogin:   "OctoCat",
+			},
+		},
+		{
+			name:      "closed and merged",
+			eventType: "pull_request",
+			fixture:   "github/pull_request_closed_merged.json",
+			want: &webhook.Event{
+				Action:        webhook.ActionMerge,
+				PullRequestID: "octo-org/api#42",
+				Title:         "Add retries to payment client",
+				AuthorLogin:   "OctoCat",
+			},
+		},
+		{name: "closed without merge", eventType: "pull_request", fixture: "github/pull_request_closed.json"},
+		{name: "ping", eventType: "ping", fixture: "github/ping.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := webhook.ParseGitHubEvent(tt.eventType, readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("ParseGitHubEvent: %v", err)
+			}
+			requireEvent(t, tt.want, event)
+		})
+	}
+}
+
+func TestParseGitHubEventInvalid(t *testing.T) {
+	if _, err := webhook.ParseGitHubEvent("pull_request", []byte("{")); !errors.Is(err, webhook.InvalidPayloadErr) {
+		t.Fatalf("malformed json: want InvalidPayloadErr, got %v", err)
+	}
+	if _, err := webhook.ParseGitHubEvent("pull_request", []byte(`{"action":"opened"}`)); !errors.Is(err, webhook.InvalidPayloadErr) {
+		t.Fatalf("missing fields: want InvalidPayloadErr, got %v", err)
+	}
+}
+
+func requireEvent(t *testing.T, want, got *webhook.Event) {
+	t.Helper()
+
+	if want == nil {
+		if got != nil {
+			t.Fatalf("want event to be ignored, got %+v", got)
+		}
+		return
+	}
+	if got == nil || *got != *want {
+		t.Fatalf("want %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"review-assigner/internal/model"
+)
+
+// Headers of GitLab webhook deliveries.
+const (
+	GitLabEventHeader = "X-Gitlab-Event"
+	GitLabTokenHeader = "X-Gitlab-Token"
+)
+
+// GitLab verifies and parses GitLab webhook deliveries.
+type GitLab struct {
+	token []byte
+}
+
+var _ Provider = (*GitLab)(nil)
+
+// NewGitLab returns GitLab which expects deliveries with secret token of webhook.
+func NewGitLab(token string) *GitLab {
+	return &GitLab{token: []byte(token)}
+}
+
+func (g *GitLab) Name() string {
+	return model.ProviderGitLab
+}
+
+// Verify compares X-Gitlab-Token header with secret token. GitLab doesn't sign deliveries.
+func (g *GitLab) Verify(header http.Header, _ []byte) error {
+	if subtle.ConstantTimeCompare([]byte(header.Get(GitLabTokenHeader)), g.token) != 1 {
+		return InvalidSignatureErr
+	}
+	return nil
+}
+
+// Parse ignores events other than merge request hooks, see ParseGitLabEvent.
+func (g *GitLab) Parse(header http.Header, body []byte) (*Event, error) {
+	return ParseGitLabEvent(header.Get(GitLabEventHeader), body)
+}
+
+type gitLabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID      int    `json:"iid"`
+		Title    string `json:"title"`
+		AuthorID int64  `json:"author_id"`
+		Action   string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+// ParseGitLabEvent parses payload of event of type given in X-Gitlab-Event header.
+// Only open, reopen and merge actions of merge request hooks are returned.
+//
+// Merge request hook tells only id of author, so AuthorLogin is set only if author triggered the event,
+// which is always the case for open action.
+func ParseGitLabEvent(eventType string, body []byte) (*Event, error) {
+	if eventType != "Merge Request Hook" {
+		return nil, nil
+	}
+
+	var payload gitLabMergeRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", InvalidPayloadErr, err)
+	}
+	if payload.ObjectKind != "merge_request" {
+		return nil, nil
+	}
+
+	var action Action
+	switch payload.ObjectAttributes.Action {
+	case "open", "reopen":
+		action = ActionOpen
+	case "merge":
+		action = ActionMerge
+	default:
+		return nil, nil
+	}
+
+	attrs := payload.ObjectAttributes
+	if payload.Project.PathWithNamespace == "" || attrs.IID == 0 {
+		return nil, fmt.Errorf("%w: project and iid are required", InvalidPayloadErr)
+	}
+
+	event := &Event{
+		Action:        action,
+		PullRequestID: truncate(fmt.Sprintf("%s!%d", payload.Project.PathWithNamespace, attrs.IID)),
+		Title:         truncate(attrs.Title),
+	}
+	if payload.User.ID != 0 && payload.User.ID == attrs.AuthorID {
+		event.AuthorLogin = payload.User.Username
+	}
+
+	return event, nil
+}
@@ -0,0 +1,109 @@
+package webhook_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"review-assigner/internal/webhook"
+)
+
+func TestGitLabVerify(t *testing.T) {
+	gitlab := webhook.NewGitLab("webhook-token")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid token", token: "webhook-token"},
+		{name: "other token", token: "other-token", wantErr: true},
+		{name: "token prefix", token: "webhook", wantErr: true},
+		{name: "missing token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.token != "" {
+				header.Set(webhook.GitLabTokenHeader, tt.token)
+			}
+
+			err := gitlab.Verify(header, readFixture(t, "gitlab/merge_request_open.json"))
+			if tt.wantErr && !errors.Is(err, webhook.InvalidSignatureErr) {
+				t.Fatalf("want InvalidSignatureErr, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("want valid token, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseGitLabEvent(t *testing.T) {
+	const eventType = "Merge Request Hook"
+
+	tests := []struct {
+		name      string
+		eventType string
+		fixture   string
+		want      *webhook.Event
+	}{
+		{
+			name:      "open by author",
+			eventType: eventType,
+			fixture:   "gitlab/merge_request_open.json",
+			want: &webhook.Event{
+				Action:        webhook.ActionOpen,
+				PullRequestID: "payments/api!7",
+				Title:         "Add retries to payment client",
+				AuthorLogin:   "JDoe",
+			},
+		},
+		{
+			// reopened by another user, so author is unknown
+			name:      "reopen by other user",
+			eventType: eventType,
+			fixture:   "gitlab/merge_request_reopen.json",
+			want: &webhook.Event{
+				Action:        webhook.ActionOpen,
+				PullRequestID: "payments/api!7",
+				Title:         "Add retries to payment client",
+			},
+		},
+		{
+			name:      "merge by other user",
+			eventType: eventType,
+			fixture:   "gitlab/merge_request_merge.json",
+			want: &webhook.Event{
+				Action:        webhook.ActionMerge,
+				PullRequestID: "payments/api!7",
+				Title:         "Add retries to payment client",
+			},
+		},
+		{name: "update", eventType: eventType, fixture: "gitlab/merge_request_update.json"},
+		{name: "other event", eventType: "Push Hook", fixture: "gitlab/merge_request_open.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := webhook.ParseGitLabEvent(tt.eventType, readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("ParseGitLabEvent: %v", err)
+			}
+			requireEvent(t, tt.want, event)
+		})
+	}
+}
+
+func TestParseGitLabEventInvalid(t *testing.T) {
+	const eventType = "Merge Request Hook"
+
+	if _, err := webhook.ParseGitLabEvent(eventType, []byte("{")); !errors.Is(err, webhook.InvalidPayloadErr) {
+		t.Fatalf("malformed json: want InvalidPayloadErr, got %v", err)
+	}
+	body := []byte(`{"object_kind":"merge_request","object_attributes":{"action":"open"}}`)
+	if _, err := webhook.ParseGitLabEvent(eventType, body); !errors.Is(err, webhook.InvalidPayloadErr) {
+		t.Fatalf("missing fields: want InvalidPayloadErr, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package webhook parses pull request events delivered by code hosting webhooks.
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"unicode/utf8"
+)
+
+var (
+	InvalidSignatureErr = errors.New("invalid webhook signature or token")
+	InvalidPayloadErr   = errors.New("invalid webhook payload")
+)
+
+// Provider verifies and parses webhook deliveries of code hosting, e.g. GitHub or GitLab.
+type Provider interface {
+	// Name identifies provider in webhook url and external identities of authors, e.g. model.ProviderGitHub.
+	Name() string
+
+	// Verify authenticates delivery, InvalidSignatureErr is returned for forged deliveries.
+	Verify(header http.Header, body []byte) error
+
+	// Parse returns event of delivery. Nil event is returned for events and actions
+	// which don't affect review assignment. InvalidPayloadErr is returned for malformed payloads.
+	Parse(header http.Header, body []byte) (*Event, error)
+}
+
+type Action string
+
+const (
+	// ActionOpen is sent when pull request is opened or reopened.
+	ActionOpen Action = "OPEN"
+	// ActionMerge is sent when pull request is merged.
+	ActionMerge Action = "MERGE"
+)
+
+// Event is pull request event relevant to review assignment.
+type Event struct {
+	Action Action
+	// PullRequestID identifies pull request across repositories, e.g. "octo-org/api#42".
+	PullRequestID string
+	Title         string
+	// AuthorLogin is login of pull request author at provider.
+	// It is empty if provider doesn't tell author of event.
+	AuthorLogin string
+}
+
+// maxFieldLength is maximum length of identifiers and names, see model validation.
+const maxFieldLength = 255
+
+// truncate cuts s to maxFieldLength runes.
+func truncate(s string) string {
+	if utf8.RuneCountInString(s) <= maxFieldLength {
+		return s
+	}
+	return string([]rune(s)[:maxFieldLength])
+}
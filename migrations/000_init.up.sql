@@ -1,4 +1,12 @@
-CREATE TYPE pull_request_status AS ENUM ('OPEN', 'MERGED');
+-- schema may already exist if database was initialized with this script before schema_migrations appeared
+DO
+$$
+BEGIN
+    CREATE TYPE pull_request_status AS ENUM ('OPEN', 'MERGED');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END;
+$$;
 
 CREATE TABLE IF NOT EXISTS teams
 (
@@ -31,5 +39,5 @@ CREATE TABLE IF NOT EXISTS review_assignments
     PRIMARY KEY (user_id, pull_request_id)
 );
 
-CREATE INDEX idx_users_team_active ON users (team_name, is_active);
-CREATE INDEX idx_pull_requests_author ON pull_requests (author_id);
+CREATE INDEX IF NOT EXISTS idx_users_team_active ON users (team_name, is_active);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author ON pull_requests (author_id);
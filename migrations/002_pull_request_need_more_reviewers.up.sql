@@ -0,0 +1,5 @@
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS need_more_reviewers BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_need_more_reviewers ON pull_requests (need_more_reviewers)
+    WHERE need_more_reviewers AND status = 'OPEN';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS assignment_events;
+
+DROP FUNCTION IF EXISTS forbid_assignment_events_change();
+
+DROP TYPE IF EXISTS assignment_event_type;
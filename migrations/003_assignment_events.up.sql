@@ -0,0 +1,37 @@
+DO
+$$
+BEGIN
+    CREATE TYPE assignment_event_type AS ENUM ('ASSIGNED', 'REASSIGNED', 'UNASSIGNED', 'MERGED');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END;
+$$;
+
+CREATE TABLE IF NOT EXISTS assignment_events
+(
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255)          NOT NULL REFERENCES pull_requests (id),
+    type            assignment_event_type NOT NULL,
+    actor           VARCHAR(255)          NOT NULL,
+    old_reviewer_id VARCHAR(255) REFERENCES users (id),
+    new_reviewer_id VARCHAR(255) REFERENCES users (id),
+    reason          VARCHAR(255)          NOT NULL,
+    created_at      TIMESTAMPTZ           NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_pull_request ON assignment_events (pull_request_id, id);
+
+-- assignment_events is append-only
+CREATE OR REPLACE FUNCTION forbid_assignment_events_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'assignment_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS assignment_events_append_only ON assignment_events;
+CREATE TRIGGER assignment_events_append_only
+    BEFORE UPDATE OR DELETE
+    ON assignment_events
+    FOR EACH ROW
+EXECUTE FUNCTION forbid_assignment_events_change();
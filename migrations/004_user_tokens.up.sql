@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_tokens
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    VARCHAR(255) NOT NULL REFERENCES users (id),
+    name       VARCHAR(255) NOT NULL,
+    token_hash CHAR(64)     NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ  NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user ON user_tokens (user_id);
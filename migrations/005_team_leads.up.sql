@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS team_leads
+(
+    team_name VARCHAR(255) REFERENCES teams (name),
+    user_id   VARCHAR(255) REFERENCES users (id),
+
+    PRIMARY KEY (team_name, user_id)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS external_identities
+(
+    provider VARCHAR(32)  NOT NULL,
+    login    VARCHAR(255) NOT NULL,
+    user_id  VARCHAR(255) NOT NULL REFERENCES users (id),
+
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_identities_user ON external_identities (user_id);
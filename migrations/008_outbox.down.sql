@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS outbox;
+DROP TYPE IF EXISTS outbox_message_status;
@@ -0,0 +1,24 @@
+DO
+$$
+BEGIN
+    CREATE TYPE outbox_message_status AS ENUM ('PENDING', 'DONE', 'FAILED');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END;
+$$;
+
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id              BIGSERIAL PRIMARY KEY,
+    sink            TEXT                  NOT NULL,
+    type            TEXT                  NOT NULL,
+    payload         JSONB                 NOT NULL,
+    status          outbox_message_status NOT NULL DEFAULT 'PENDING',
+    attempts        INTEGER               NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ           NOT NULL,
+    last_error      TEXT                  NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ           NOT NULL,
+    processed_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_due ON outbox (next_attempt_at) WHERE status = 'PENDING';
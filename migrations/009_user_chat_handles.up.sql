@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS chat_handle VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS user_digests;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email;
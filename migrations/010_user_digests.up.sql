@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS user_digests
+(
+    user_id      VARCHAR(255) PRIMARY KEY REFERENCES users (id),
+    opt_out      BOOLEAN      NOT NULL DEFAULT FALSE,
+    last_sent_at TIMESTAMPTZ
+);
@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS pull_request_escalations;
+
+-- enum values can't be dropped, so type is recreated without ESCALATED events
+ALTER TABLE assignment_events
+    DISABLE TRIGGER assignment_events_append_only;
+DELETE
+FROM assignment_events
+WHERE type = 'ESCALATED';
+ALTER TABLE assignment_events
+    ENABLE TRIGGER assignment_events_append_only;
+
+ALTER TYPE assignment_event_type RENAME TO assignment_event_type_old;
+CREATE TYPE assignment_event_type AS ENUM ('ASSIGNED', 'REASSIGNED', 'UNASSIGNED', 'MERGED');
+ALTER TABLE assignment_events
+    ALTER COLUMN type TYPE assignment_event_type USING type::text::assignment_event_type;
+DROP TYPE assignment_event_type_old;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS review_sla_auto_reassign;
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS review_sla_seconds;
@@ -0,0 +1,12 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS review_sla_seconds INTEGER NOT NULL DEFAULT 0 CHECK (review_sla_seconds >= 0);
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS review_sla_auto_reassign BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TYPE assignment_event_type ADD VALUE IF NOT EXISTS 'ESCALATED';
+
+CREATE TABLE IF NOT EXISTS pull_request_escalations
+(
+    pull_request_id VARCHAR(255) PRIMARY KEY REFERENCES pull_requests (id),
+    escalated_at    TIMESTAMPTZ NOT NULL
+);
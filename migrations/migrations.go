@@ -0,0 +1,11 @@
+// Package migrations embeds postgres schema migrations.
+//
+// Every migration is a pair of files NNN_name.up.sql and NNN_name.down.sql,
+// where NNN is version. Migrations are applied in order of versions.
+// Versions may have gaps, e.g. 007 was folded into 008_outbox.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS